@@ -5,6 +5,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/interop"
 	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
 	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
 	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
@@ -42,6 +43,8 @@ const (
 	netmapContractKey    = "netmapScriptHash"
 	containerContractKey = "containerScriptHash"
 	notaryDisabledKey    = "notary"
+
+	feeEscrowPrefix = "feeEscrow"
 )
 
 var token Token
@@ -251,6 +254,75 @@ func Lock(txDetails []byte, from, to interop.Hash160, amount, until int) {
 	runtime.Notify("Lock", txDetails, from, to, amount, until)
 }
 
+// LockFee is a method that transfers assets from a user account to the escrow
+// account of the container fee. It can be invoked only by Container contract.
+//
+// It produces FeeLocked, Transfer and TransferX notifications.
+//
+// LockFee method is invoked by Container contract when the container owner
+// requests an operation with a fee in notary-disabled environment. Locked assets
+// are returned to the user with UnlockFee when the operation is approved by
+// Alphabet nodes of the Inner Ring, so the fee can be paid. If the operation
+// is not approved until the specified epoch, the assets are returned to the user
+// on NewEpoch.
+func LockFee(id []byte, from interop.Hash160, amount, until int) {
+	ctx := storage.GetContext()
+
+	if !common.FromKnownContract(ctx, runtime.GetCallingScriptHash(), containerContractKey) {
+		panic("this method must be invoked by container contract")
+	}
+
+	escrow := feeEscrowAddress(id)
+	if storage.Get(ctx, escrow) != nil {
+		panic("fee is already locked")
+	}
+
+	common.SetSerialized(ctx, escrow, Account{
+		Balance: 0,
+		Until:   until,
+		Parent:  from,
+	})
+
+	result := token.transfer(ctx, from, escrow, amount, true, common.LockTransferDetails(id))
+	if !result {
+		panic("can't lock funds")
+	}
+
+	runtime.Log("container fee locked")
+	runtime.Notify("FeeLocked", id, from, escrow, amount, until)
+}
+
+// UnlockFee is a method that returns assets locked with LockFee back to the user
+// account. It can be invoked only by Container contract. It does nothing if
+// there are no locked assets.
+//
+// It produces Transfer and TransferX notifications.
+func UnlockFee(id []byte) {
+	ctx := storage.GetContext()
+
+	if !common.FromKnownContract(ctx, runtime.GetCallingScriptHash(), containerContractKey) {
+		panic("this method must be invoked by container contract")
+	}
+
+	escrow := feeEscrowAddress(id)
+	acc := getAccount(ctx, escrow)
+	if acc.Balance == 0 {
+		storage.Delete(ctx, escrow)
+		return
+	}
+
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	epoch := contract.Call(netmapContractAddr, "epoch", contract.ReadOnly).(int)
+	token.transfer(ctx, escrow, acc.Parent, acc.Balance, true, common.UnlockTransferDetails(epoch))
+	runtime.Log("container fee unlocked")
+}
+
+// LockedFee method returns the amount of assets locked with LockFee.
+func LockedFee(id []byte) int {
+	ctx := storage.GetReadOnlyContext()
+	return token.balanceOf(ctx, feeEscrowAddress(id))
+}
+
 // NewEpoch is a method that checks timeout on lock accounts and returns assets
 // if lock is not available anymore. It can be invoked only by NewEpoch method
 // of Netmap contract.
@@ -360,13 +432,17 @@ func Mint(to interop.Hash160, amount int, txDetails []byte) {
 // Before that, Alphabet nodes should synchronize precision of mainchain GAS
 // contract and Balance contract. Burn decreases total supply of NEP-17
 // compatible FrostFS token.
+//
+// Container contract also invokes Burn to burn a share of the container fee
+// if it is configured.
 func Burn(from interop.Hash160, amount int, txDetails []byte) {
 	ctx := storage.GetContext()
 	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
 
 	var ( // for invocation collection without notary
-		alphabet []interop.PublicKey
-		nodeKey  []byte
+		alphabet     []interop.PublicKey
+		nodeKey      []byte
+		indirectCall bool
 	)
 
 	if notaryDisabled {
@@ -375,6 +451,12 @@ func Burn(from interop.Hash160, amount int, txDetails []byte) {
 		if len(nodeKey) == 0 {
 			panic("this method must be invoked from inner ring")
 		}
+
+		indirectCall = common.FromKnownContract(
+			ctx,
+			runtime.GetCallingScriptHash(),
+			containerContractKey,
+		)
 	} else {
 		multiaddr := common.AlphabetAddress()
 		common.CheckAlphabetWitness(multiaddr)
@@ -382,7 +464,7 @@ func Burn(from interop.Hash160, amount int, txDetails []byte) {
 
 	details := common.BurnTransferDetails(txDetails)
 
-	if notaryDisabled {
+	if notaryDisabled && !indirectCall {
 		threshold := len(alphabet)*2/3 + 1
 		id := common.InvokeID([]interface{}{txDetails}, []byte("burn"))
 
@@ -501,6 +583,12 @@ func isUsableAddress(addr interop.Hash160) bool {
 	return false
 }
 
+// feeEscrowAddress returns the address of the escrow account of the
+// container fee, see LockFee.
+func feeEscrowAddress(id []byte) interop.Hash160 {
+	return crypto.Ripemd160(crypto.Sha256(append([]byte(feeEscrowPrefix), id...)))
+}
+
 func getAccount(ctx storage.Context, key interface{}) Account {
 	data := storage.Get(ctx, key)
 	if data != nil {
@@ -15,6 +15,11 @@ minting of FROSTFS tokens in Balance contract. Withdraw locks some FROSTFS token
 in a special lock account. When FrostFS contract transfers GAS assets back to the
 user, the lock account is destroyed with burn operation.
 
+Container fees requested by container owners in notary-disabled environment
+are locked in escrow accounts until the request is approved by Alphabet nodes
+of the Inner Ring. Assets are returned to the owner if the request is not
+approved in time.
+
 # Contract notifications
 
 Transfer notification. This is a NEP-17 standard notification.
@@ -57,6 +62,24 @@ Cheque method invocation of FrostFS contract.
 	  - name: until
 	    type: Integer
 
+FeeLocked notification. This notification is produced when a container fee is
+locked on the escrow account until the request is approved by Alphabet nodes of
+the Inner Ring. It contains the request ID, the address of the escrow account and
+the FrostFS epoch number until which the fee is locked. Unlike Lock, it doesn't
+initiate any mainchain operation.
+
+	FeeLocked:
+	  - name: id
+	    type: ByteArray
+	  - name: from
+	    type: Hash160
+	  - name: to
+	    type: Hash160
+	  - name: amount
+	    type: Integer
+	  - name: until
+	    type: Integer
+
 Mint notification. This notification is produced when user balance is
 replenished from deposit in the mainchain.
 
@@ -13,6 +13,13 @@ var (
 	containerFeePrefix = []byte{0x10}
 )
 
+// Recipients of the container fee share, see ContainerFeeShareTransferDetails.
+const (
+	ContainerFeeAlphabetShare = 0x01
+	ContainerFeeTreasuryShare = 0x02
+	ContainerFeeBurnShare     = 0x03
+)
+
 func WalletToScriptHash(wallet []byte) []byte {
 	// V2 format
 	return wallet[1 : len(wallet)-4]
@@ -39,6 +46,13 @@ func ContainerFeeTransferDetails(cid []byte) []byte {
 	return append(containerFeePrefix, cid...)
 }
 
+// ContainerFeeShareTransferDetails is similar to ContainerFeeTransferDetails,
+// but also encodes the recipient of the fee share when container fee is split
+// between Alphabet nodes, the treasury and burning.
+func ContainerFeeShareTransferDetails(cid []byte, recipient byte) []byte {
+	return append(ContainerFeeTransferDetails(cid), recipient)
+}
+
 // AbortWithMessage calls `runtime.Log` with the passed message
 // and calls `ABORT` opcode.
 func AbortWithMessage(msg string) {
@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/interop/convert"
 	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/crypto"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/ledger"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
 	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
@@ -14,10 +15,6 @@ import (
 )
 
 type (
-	storageNode struct {
-		info []byte
-	}
-
 	Container struct {
 		value []byte
 		sig   interop.Signature
@@ -41,6 +38,63 @@ type (
 		cid         []byte
 		estimations []estimation
 	}
+
+	// SizeSummary contains aggregated size estimations of the container for
+	// a single epoch. Estimations which deviate from the median too much are
+	// not taken into account.
+	SizeSummary struct {
+		// Nodes is the number of estimations taken into account.
+		Nodes int
+		// Median is the median of estimations.
+		Median int
+		// Min is the smallest estimation.
+		Min int
+		// Max is the largest estimation.
+		Max int
+	}
+
+	// UsageInfo contains the total size of containers of the owner in some
+	// epoch.
+	UsageInfo struct {
+		Owner []byte
+		Size  int
+	}
+
+	// EACLHistoryItem contains the extended ACL of the container along with
+	// the epoch and the block height when it was set.
+	EACLHistoryItem struct {
+		EACL   ExtendedACL
+		Epoch  int
+		Height int
+	}
+
+	// ContainerSize contains the size of the container estimated by a storage
+	// node, see PutContainerSizes.
+	ContainerSize struct {
+		CID  []byte
+		Size int
+	}
+
+	attribute struct {
+		key   []byte
+		value []byte
+	}
+
+	placementPolicy struct {
+		// replicas contains the maximum number of object copies among
+		// replica descriptors of the policy.
+		replicas int
+		// subnet contains stable marshaled subnet ID, it is empty
+		// for zero subnet.
+		subnet []byte
+	}
+
+	// DelInfo contains ID of the owner of the deleted container and
+	// the epoch when the container was deleted.
+	DelInfo struct {
+		Owner []byte
+		Epoch int
+	}
 )
 
 const (
@@ -48,6 +102,7 @@ const (
 	balanceContractKey   = "balanceScriptHash"
 	netmapContractKey    = "netmapScriptHash"
 	nnsContractKey       = "nnsScriptHash"
+	subnetContractKey    = "subnetScriptHash"
 	nnsRootKey           = "nnsRoot"
 	nnsHasAliasKey       = "nnsHasAlias"
 	notaryDisabledKey    = "notary"
@@ -56,15 +111,62 @@ const (
 	RegistrationFeeKey = "ContainerFee"
 	// AliasFeeKey is a key in netmap config which contains fee for nice-name registration.
 	AliasFeeKey = "ContainerAliasFee"
+	// TombstoneRetentionKey is a key in netmap config which contains the number
+	// of epochs during which information about deleted containers is stored.
+	TombstoneRetentionKey = "ContainerTombstoneRetention"
+	// DefaultQuotaKey is a key in netmap config which contains the default
+	// size limit of a container in bytes. Zero or missing value means no limit.
+	DefaultQuotaKey = "ContainerDefaultQuota"
+	// FeeAlphabetShareKey is a key in netmap config which contains the share of
+	// container and alias fees in percents divided between Alphabet nodes. If it
+	// is missing, each Alphabet node receives the whole fee.
+	FeeAlphabetShareKey = "ContainerFeeAlphabetShare"
+	// FeeBurnShareKey is a key in netmap config which contains the share of
+	// container and alias fees in percents which is burnt.
+	FeeBurnShareKey = "ContainerFeeBurnShare"
+	// FeeTreasuryKey is a key in netmap config which contains the script hash
+	// of the treasury account receiving the rest of container and alias fees.
+	FeeTreasuryKey = "ContainerFeeTreasury"
+	// FeeEscrowPeriodKey is a key in netmap config which contains the number of
+	// epochs during which the fee locked at request time waits for the approval
+	// of Alphabet nodes in notary-disabled environment.
+	FeeEscrowPeriodKey = "ContainerFeeEscrowPeriod"
+	// IndexedAttributesKey is a key in netmap config which contains
+	// comma-separated keys of container attributes indexed for
+	// ContainersByAttribute. DefaultIndexedAttributes are indexed if
+	// it is missing.
+	IndexedAttributesKey = "ContainerIndexedAttributes"
 
 	// V2 format
 	containerIDSize = 32 // SHA256 size
+	ownerSize       = 25
+
+	singleEstimatePrefix  = "est"
+	estimateKeyPrefix     = "cnr"
+	containerKeyPrefix    = 'x'
+	ownerKeyPrefix        = 'o'
+	containerCountKey     = "containerCount"
+	ownerCountKeyPrefix   = 'k'
+	deletedKeyPrefix      = 'd'
+	deletedEpochPrefix    = 't'
+	tombstoneExpiryPrefix = 'r'
+	attributeKeyPrefix    = 'a'
+	ownerOverridePrefix   = 'w'
+	eACLHistoryPrefix     = 'h'
+	sizeSummaryPrefix     = 'z'
+	ownerUsagePrefix      = 'u'
+	quotaKeyPrefix        = 'q'
+	nameKeyPrefix         = 'm'
+	migrationKeyPrefix    = 'g'
+	epochKeySize          = 8
+	estimatePostfixSize   = 10
+
+	// Names of the one-time storage migrations.
+	countersMigration   = "counters"
+	attributesMigration = "attributes"
+	orphansMigration    = "orphans"
+	namesMigration      = "names"
 
-	singleEstimatePrefix = "est"
-	estimateKeyPrefix    = "cnr"
-	containerKeyPrefix   = 'x'
-	ownerKeyPrefix       = 'o'
-	estimatePostfixSize  = 10
 	// CleanupDelta contains the number of the last epochs for which container estimations are present.
 	CleanupDelta = 3
 	// TotalCleanupDelta contains the number of the epochs after which estimation
@@ -72,8 +174,46 @@ const (
 	// container size and/or container has been removed. It must be greater than CleanupDelta.
 	TotalCleanupDelta = CleanupDelta + 1
 
+	// DefaultTombstoneRetention contains the number of epochs during which
+	// information about deleted containers is stored if it is not specified
+	// in netmap config.
+	DefaultTombstoneRetention = 100
+
+	// MaxPageSize contains the maximum number of container IDs returned by
+	// ContainersOfPaged method in a single call.
+	MaxPageSize = 1024
+
+	// MaxEACLHistorySize contains the maximum number of extended ACL changes
+	// stored for each container.
+	MaxEACLHistorySize = 16
+
+	// MaxEstimationDeviation contains the maximum deviation of a container size
+	// estimation from the median, in percents. Estimations which deviate more
+	// are considered outliers and are not taken into account in SizeSummary.
+	MaxEstimationDeviation = 50
+
+	// DefaultIndexedAttributes contains comma-separated keys of container
+	// attributes indexed if they are not specified in netmap config.
+	DefaultIndexedAttributes = "Name,Zone,__NEOFS__NAME,__NEOFS__ZONE"
+
+	// DefaultFeeEscrowPeriod contains the number of epochs during which the
+	// fee is locked if it is not specified in netmap config.
+	DefaultFeeEscrowPeriod = 10
+
 	// NotFoundError is returned if container is missing.
 	NotFoundError = "container does not exist"
+	// AlreadyExistsError is returned if container with the same ID already exists
+	// or has been recently deleted.
+	AlreadyExistsError = "container already exists"
+	// InvalidOwnerKeyError is returned if the public key is neither the key of
+	// the container owner nor bound to the owner in FrostFSID contract.
+	InvalidOwnerKeyError = "public key is not bound to the container owner"
+	// NotEnoughNodesError is returned if the placement policy of the container
+	// requires more nodes than there are in the current network map.
+	NotEnoughNodesError = "placement policy requires more nodes than there are in the network map"
+	// SubnetNotSupportedError is returned if the placement policy of the container
+	// references non-zero subnet while subnet contract is not set.
+	SubnetNotSupportedError = "placement policy references subnet, but subnet contract is not set"
 
 	// default SOA record field values
 	defaultRefresh = 3600                 // 1 hour
@@ -95,6 +235,10 @@ func _deploy(data interface{}, isUpdate bool) {
 	if isUpdate {
 		args := data.([]interface{})
 		common.CheckVersion(args[len(args)-1].(int))
+		if len(args) > 1 {
+			// Subnet contract can be set during the update.
+			storage.Put(ctx, subnetContractKey, args[0].(interop.Hash160))
+		}
 
 		it := storage.Find(ctx, []byte{}, storage.None)
 		for iterator.Next(it) {
@@ -115,6 +259,23 @@ func _deploy(data interface{}, isUpdate bool) {
 				storage.Put(ctx, append([]byte{ownerKeyPrefix}, item.key...), item.value)
 			}
 		}
+
+		if !isMigrated(ctx, countersMigration) {
+			initContainerCounters(ctx)
+			setMigrated(ctx, countersMigration)
+		}
+		if !isMigrated(ctx, attributesMigration) {
+			initAttributeIndex(ctx)
+			setMigrated(ctx, attributesMigration)
+		}
+		if !isMigrated(ctx, namesMigration) {
+			initNameIndex(ctx)
+			setMigrated(ctx, namesMigration)
+		}
+		if !isMigrated(ctx, orphansMigration) {
+			cleanupOrphanedRecords(ctx)
+			setMigrated(ctx, orphansMigration)
+		}
 		return
 	}
 
@@ -125,6 +286,7 @@ func _deploy(data interface{}, isUpdate bool) {
 		addrID         interop.Hash160
 		addrNNS        interop.Hash160
 		nnsRoot        string
+		addrSubnet     interop.Hash160
 	})
 
 	if len(args.addrNetmap) != interop.Hash160Len ||
@@ -138,6 +300,15 @@ func _deploy(data interface{}, isUpdate bool) {
 	storage.Put(ctx, frostfsIDContractKey, args.addrID)
 	storage.Put(ctx, nnsContractKey, args.addrNNS)
 	storage.Put(ctx, nnsRootKey, args.nnsRoot)
+	if len(args.addrSubnet) == interop.Hash160Len {
+		storage.Put(ctx, subnetContractKey, args.addrSubnet)
+	}
+
+	// New contract has nothing to migrate.
+	setMigrated(ctx, countersMigration)
+	setMigrated(ctx, attributesMigration)
+	setMigrated(ctx, orphansMigration)
+	setMigrated(ctx, namesMigration)
 
 	// initialize the way to collect signatures
 	storage.Put(ctx, notaryDisabledKey, args.notaryDisabled)
@@ -167,6 +338,54 @@ func registerNiceNameTLD(addrNNS interop.Hash160, nnsRoot string) {
 	}
 }
 
+// RejectPut method returns the container fee locked by Put or PutNamed request
+// to the owner if it was invoked by Alphabet nodes of the Inner Ring. Alphabet
+// nodes invoke it when they decline to create the container. It does nothing
+// if there is no locked fee, e.g. in notary-enabled environment.
+func RejectPut(containerID []byte) {
+	rejectRequest(containerID, containerID, "rejectPut")
+}
+
+// RejectAlias method returns the alias fee locked by SetAlias request to the
+// owner if it was invoked by Alphabet nodes of the Inner Ring. Alphabet nodes
+// invoke it when they decline to set the alias. It does nothing if there is
+// no locked fee, e.g. in notary-enabled environment.
+func RejectAlias(containerID []byte) {
+	rejectRequest(containerID, append([]byte(nnsHasAliasKey), containerID...), "rejectAlias")
+}
+
+// rejectRequest unlocks the fee with the specified escrow ID once the request
+// is rejected by Alphabet nodes.
+func rejectRequest(containerID, escrowID []byte, method string) {
+	ctx := storage.GetContext()
+	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
+
+	if notaryDisabled {
+		alphabet := common.AlphabetNodes()
+		nodeKey := common.InnerRingInvoker(alphabet)
+		if len(nodeKey) == 0 {
+			panic("method must be invoked by inner ring")
+		}
+
+		threshold := len(alphabet)*2/3 + 1
+		id := common.InvokeID([]interface{}{containerID}, []byte(method))
+
+		n := common.Vote(ctx, id, nodeKey)
+		if n < threshold {
+			return
+		}
+
+		common.RemoveVotes(ctx, id)
+	} else {
+		multiaddr := common.AlphabetAddress()
+		common.CheckAlphabetWitness(multiaddr)
+	}
+
+	balanceContractAddr := storage.Get(ctx, balanceContractKey).(interop.Hash160)
+	contract.Call(balanceContractAddr, "unlockFee", contract.All, escrowID)
+	runtime.Log("request rejected, fee returned")
+}
+
 // Update method updates contract source code and manifest. It can be invoked
 // by committee only.
 func Update(script []byte, manifest []byte, data interface{}) {
@@ -181,6 +400,10 @@ func Update(script []byte, manifest []byte, data interface{}) {
 
 // Put method creates a new container if it has been invoked by Alphabet nodes
 // of the Inner Ring. Otherwise, it produces containerPut notification.
+// In notary-disabled environment, container fee is locked in Balance contract
+// at this moment, so the request must be witnessed by the owner. The fee is returned to the owner if the request is rejected
+// with RejectPut or is not approved during FeeEscrowPeriodKey netmap config
+// epochs (DefaultFeeEscrowPeriod by default).
 //
 // Container should be a stable marshaled Container structure from API.
 // Signature is a RFC6979 signature of the Container.
@@ -193,6 +416,13 @@ func Put(container []byte, signature interop.Signature, publicKey interop.Public
 
 // PutNamed is similar to put but also sets a TXT record in nns contract.
 // Note that zone must exist.
+//
+// If the container with the same ID already exists or has been deleted
+// recently (see DeletionInfo), it panics with AlreadyExistsError.
+//
+// If the placement policy of the container requires more replicas than there
+// are nodes in the current network map, it panics with NotEnoughNodesError.
+// Non-zero subnet of the placement policy must exist in the subnet contract.
 func PutNamed(container []byte, signature interop.Signature,
 	publicKey interop.PublicKey, token []byte,
 	name, zone string) {
@@ -201,6 +431,13 @@ func PutNamed(container []byte, signature interop.Signature,
 
 	ownerID := ownerFromBinaryContainer(container)
 	containerID := crypto.Sha256(container)
+	if storage.Get(ctx, append([]byte{containerKeyPrefix}, containerID...)) != nil ||
+		storage.Get(ctx, append([]byte{deletedKeyPrefix}, containerID...)) != nil {
+		panic(AlreadyExistsError)
+	}
+
+	checkPlacementPolicy(ctx, container)
+
 	frostfsIDContractAddr := storage.Get(ctx, frostfsIDContractKey).(interop.Hash160)
 	cnr := Container{
 		value: container,
@@ -227,20 +464,25 @@ func PutNamed(container []byte, signature interop.Signature,
 	from := common.WalletToScriptHash(ownerID)
 	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
 	balanceContractAddr := storage.Get(ctx, balanceContractKey).(interop.Hash160)
-	containerFee := contract.Call(netmapContractAddr, "config", contract.ReadOnly, RegistrationFeeKey).(int)
+	containerFee := netmapConfig(netmapContractAddr, RegistrationFeeKey).(int)
 	balance := contract.Call(balanceContractAddr, "balanceOf", contract.ReadOnly, from).(int)
 	if name != "" {
-		aliasFee := contract.Call(netmapContractAddr, "config", contract.ReadOnly, AliasFeeKey).(int)
+		aliasFee := netmapConfig(netmapContractAddr, AliasFeeKey).(int)
 		containerFee += aliasFee
 	}
 
-	if balance < containerFee*len(alphabet) {
+	amount := feeAmount(netmapContractAddr, containerFee, len(alphabet))
+	if notaryDisabled {
+		balance += contract.Call(balanceContractAddr, "lockedFee", contract.ReadOnly, containerID).(int)
+	}
+	if balance < amount {
 		panic("insufficient balance to create container")
 	}
 
 	if notaryDisabled {
 		nodeKey := common.InnerRingInvoker(alphabet)
 		if len(nodeKey) == 0 {
+			lockFee(netmapContractAddr, balanceContractAddr, from, containerID, amount)
 			runtime.Notify("containerPut", container, signature, publicKey, token)
 			return
 		}
@@ -254,51 +496,148 @@ func PutNamed(container []byte, signature interop.Signature,
 		}
 
 		common.RemoveVotes(ctx, id)
+		contract.Call(balanceContractAddr, "unlockFee", contract.All, containerID)
 	} else {
 		multiaddr := common.AlphabetAddress()
 		common.CheckAlphabetWitness(multiaddr)
 	}
-	// todo: check if new container with unique container id
 
+	transferFee(netmapContractAddr, balanceContractAddr, from, alphabet, containerFee, containerID)
+
+	addContainer(ctx, containerID, ownerID, cnr)
+
+	if name != "" {
+		addAlias(ctx, nnsContractAddr, containerID, domain, needRegister)
+	}
+
+	if len(token) == 0 { // if container created directly without session
+		contract.Call(frostfsIDContractAddr, "addKey", contract.All, ownerID, [][]byte{publicKey})
+	}
+
+	runtime.Log("added new container")
+	runtime.Notify("PutSuccess", containerID, publicKey)
+}
+
+// getFeeDistribution returns the shares of the container fee in percents paid
+// to Alphabet nodes and burnt along with the treasury account receiving the
+// rest of the fee. Negative Alphabet share is returned if the distribution is
+// not configured.
+func getFeeDistribution(netmapContractAddr interop.Hash160) (int, int, interop.Hash160) {
+	value := netmapConfig(netmapContractAddr, FeeAlphabetShareKey)
+	if value == nil {
+		return -1, 0, nil
+	}
+	alphabetShare := value.(int)
+
+	burnShare := 0
+	value = netmapConfig(netmapContractAddr, FeeBurnShareKey)
+	if value != nil {
+		burnShare = value.(int)
+	}
+
+	if alphabetShare < 0 || burnShare < 0 || alphabetShare+burnShare > 100 {
+		panic("invalid container fee distribution")
+	}
+
+	var treasury interop.Hash160
+	if alphabetShare+burnShare < 100 {
+		value = netmapConfig(netmapContractAddr, FeeTreasuryKey)
+		if value == nil || len(value.(interop.Hash160)) != interop.Hash160Len {
+			panic("container fee treasury is not set")
+		}
+		treasury = value.(interop.Hash160)
+	}
+
+	return alphabetShare, burnShare, treasury
+}
+
+// feeAmount returns the amount charged from the container owner for the fee.
+// If fee distribution is not configured, each Alphabet node receives the whole
+// fee. Otherwise, the fee is split according to the configured shares.
+func feeAmount(netmapContractAddr interop.Hash160, fee, alphabetCount int) int {
+	alphabetShare, _, _ := getFeeDistribution(netmapContractAddr)
+	if alphabetShare < 0 {
+		return fee * alphabetCount
+	}
+	return fee
+}
+
+// transferFee transfers the fee from the container owner to Alphabet nodes
+// and the treasury and burns the rest, see feeAmount.
+func transferFee(netmapContractAddr, balanceContractAddr interop.Hash160, from []byte,
+	alphabet []interop.PublicKey, fee int, containerID []byte) {
+	alphabetShare, burnShare, treasury := getFeeDistribution(netmapContractAddr)
+
+	nodeFee := fee
 	details := common.ContainerFeeTransferDetails(containerID)
+	if alphabetShare >= 0 {
+		nodeFee = fee * alphabetShare / 100 / len(alphabet)
+		details = common.ContainerFeeShareTransferDetails(containerID, common.ContainerFeeAlphabetShare)
+	}
+
+	if nodeFee > 0 {
+		for i := 0; i < len(alphabet); i++ {
+			node := alphabet[i]
+			to := contract.CreateStandardAccount(node)
+
+			contract.Call(balanceContractAddr, "transferX",
+				contract.All,
+				from,
+				to,
+				nodeFee,
+				details,
+			)
+		}
+	}
+
+	if alphabetShare < 0 {
+		return
+	}
 
-	for i := 0; i < len(alphabet); i++ {
-		node := alphabet[i]
-		to := contract.CreateStandardAccount(node)
+	// Remainder of the integer division goes to the treasury
+	// or is burnt if there is no treasury share.
+	burnFee := fee * burnShare / 100
+	treasuryFee := fee - nodeFee*len(alphabet) - burnFee
+	if len(treasury) == 0 {
+		burnFee += treasuryFee
+		treasuryFee = 0
+	}
 
+	if treasuryFee > 0 {
 		contract.Call(balanceContractAddr, "transferX",
 			contract.All,
 			from,
-			to,
-			containerFee,
-			details,
+			treasury,
+			treasuryFee,
+			common.ContainerFeeShareTransferDetails(containerID, common.ContainerFeeTreasuryShare),
 		)
 	}
 
-	addContainer(ctx, containerID, ownerID, cnr)
-
-	if name != "" {
-		if needRegister {
-			res := contract.Call(nnsContractAddr, "register", contract.All,
-				domain, runtime.GetExecutingScriptHash(), "ops@nspcc.ru",
-				defaultRefresh, defaultRetry, defaultExpire, defaultTTL).(bool)
-			if !res {
-				panic("can't register the domain " + domain)
-			}
-		}
-		contract.Call(nnsContractAddr, "addRecord", contract.All,
-			domain, 16 /* TXT */, std.Base58Encode(containerID))
-
-		key := append([]byte(nnsHasAliasKey), containerID...)
-		storage.Put(ctx, key, domain)
+	if burnFee > 0 {
+		contract.Call(balanceContractAddr, "burn",
+			contract.All,
+			from,
+			burnFee,
+			common.ContainerFeeShareTransferDetails(containerID, common.ContainerFeeBurnShare),
+		)
 	}
+}
 
-	if len(token) == 0 { // if container created directly without session
-		contract.Call(frostfsIDContractAddr, "addKey", contract.All, ownerID, [][]byte{publicKey})
+// lockFee locks the fee on the escrow account in Balance contract until the
+// request of the owner is approved or rejected by Alphabet nodes or the escrow
+// period (FeeEscrowPeriodKey netmap config) passes. The owner must witness the
+// request, otherwise anyone could lock the owner funds with forged requests.
+func lockFee(netmapContractAddr, balanceContractAddr interop.Hash160, from, id []byte, amount int) {
+	common.CheckWitness(from)
+
+	period := DefaultFeeEscrowPeriod
+	value := netmapConfig(netmapContractAddr, FeeEscrowPeriodKey)
+	if value != nil {
+		period = value.(int)
 	}
+	epoch := contract.Call(netmapContractAddr, "epoch", contract.ReadOnly).(int)
 
-	runtime.Log("added new container")
-	runtime.Notify("PutSuccess", containerID, publicKey)
+	contract.Call(balanceContractAddr, "lockFee", contract.All, id, from, amount, epoch+period)
 }
 
 // checkNiceNameAvailable checks if the nice name is available for the container.
@@ -325,34 +664,103 @@ func checkNiceNameAvailable(nnsContractAddr interop.Hash160, domain string) bool
 	return false
 }
 
-// Delete method removes a container from the contract storage if it has been
-// invoked by Alphabet nodes of the Inner Ring. Otherwise, it produces
-// containerDelete notification.
+// addAlias sets NNS TXT record of the domain to the container ID and registers
+// the domain if needed, see checkNiceNameAvailable.
+func addAlias(ctx storage.Context, nnsContractAddr interop.Hash160, containerID []byte, domain string, needRegister bool) {
+	if needRegister {
+		res := contract.Call(nnsContractAddr, "register", contract.All,
+			domain, runtime.GetExecutingScriptHash(), "ops@nspcc.ru",
+			defaultRefresh, defaultRetry, defaultExpire, defaultTTL).(bool)
+		if !res {
+			panic("can't register the domain " + domain)
+		}
+	}
+	contract.Call(nnsContractAddr, "addRecord", contract.All,
+		domain, 16 /* TXT */, std.Base58Encode(containerID))
+
+	key := append([]byte(nnsHasAliasKey), containerID...)
+	storage.Put(ctx, key, domain)
+	storage.Put(ctx, nameIndexKey(domain), containerID)
+}
+
+// removeAlias removes the alias of the container along with NNS TXT record
+// and returns the domain. Empty string is returned if the container has no alias.
+func removeAlias(ctx storage.Context, containerID []byte) string {
+	key := append([]byte(nnsHasAliasKey), containerID...)
+	domain := storage.Get(ctx, key).(string)
+	if len(domain) != 0 {
+		storage.Delete(ctx, key)
+		storage.Delete(ctx, nameIndexKey(domain))
+		// We should do `getRecord` first because NNS record could be deleted
+		// by other means (expiration, manual), thus leading to failing `deleteRecord`
+		// and inability to delete a container. We should also check if we own the record in case.
+		nnsContractAddr := storage.Get(ctx, nnsContractKey).(interop.Hash160)
+		res := contract.Call(nnsContractAddr, "getRecords", contract.ReadStates|contract.AllowCall, domain, 16 /* TXT */)
+		if res != nil && std.Base58Encode(containerID) == string(res.([]interface{})[0].(string)) {
+			contract.Call(nnsContractAddr, "deleteRecords", contract.All, domain, 16 /* TXT */)
+		}
+	}
+	return domain
+}
+
+// SetAlias method sets the nice name of the existing container if it was
+// invoked by Alphabet nodes of the Inner Ring. Otherwise, it produces setAlias
+// notification. The previous alias of the container is removed. Alias fee is
+// locked at request time in notary-disabled environment, like in Put, and is
+// returned if the request is rejected with RejectAlias.
 //
-// Signature is a RFC6979 signature of the container ID.
+// Name and zone are the same as in PutNamed, the root zone is used if the
+// zone is empty. Alias fee (AliasFeeKey netmap config) is charged from the
+// container owner.
+// Signature is a RFC6979 signature of the container ID and the domain.
 // Token is optional and should be a stable marshaled SessionToken structure from
 // API.
 //
 // If the container doesn't exist, it panics with NotFoundError.
-func Delete(containerID []byte, signature interop.Signature, token []byte) {
+func SetAlias(containerID []byte, name, zone string, signature interop.Signature, token []byte) {
 	ctx := storage.GetContext()
 	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
 
 	ownerID := getOwnerByID(ctx, containerID)
 	if ownerID == nil {
-		return
+		panic(NotFoundError)
+	}
+	if name == "" {
+		panic("empty container alias")
+	}
+	if zone == "" {
+		zone = storage.Get(ctx, nnsRootKey).(string)
+	}
+
+	nnsContractAddr := storage.Get(ctx, nnsContractKey).(interop.Hash160)
+	domain := name + "." + zone
+	needRegister := checkNiceNameAvailable(nnsContractAddr, domain)
+
+	alphabet := common.AlphabetNodes()
+	from := common.WalletToScriptHash(ownerID)
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	balanceContractAddr := storage.Get(ctx, balanceContractKey).(interop.Hash160)
+	aliasFee := netmapConfig(netmapContractAddr, AliasFeeKey).(int)
+	balance := contract.Call(balanceContractAddr, "balanceOf", contract.ReadOnly, from).(int)
+	escrowID := append([]byte(nnsHasAliasKey), containerID...)
+	amount := feeAmount(netmapContractAddr, aliasFee, len(alphabet))
+	if notaryDisabled {
+		balance += contract.Call(balanceContractAddr, "lockedFee", contract.ReadOnly, escrowID).(int)
+	}
+	if balance < amount {
+		panic("insufficient balance to set container alias")
 	}
 
 	if notaryDisabled {
-		alphabet := common.AlphabetNodes()
 		nodeKey := common.InnerRingInvoker(alphabet)
 		if len(nodeKey) == 0 {
-			runtime.Notify("containerDelete", containerID, signature, token)
+			lockFee(netmapContractAddr, balanceContractAddr, from, escrowID, amount)
+			runtime.Notify("setAlias", containerID, name, zone, signature, token)
 			return
 		}
 
 		threshold := len(alphabet)*2/3 + 1
-		id := common.InvokeID([]interface{}{containerID, signature}, []byte("delete"))
+		id := common.InvokeID([]interface{}{containerID, domain, signature}, []byte("setAlias"))
 
 		n := common.Vote(ctx, id, nodeKey)
 		if n < threshold {
@@ -360,86 +768,374 @@ func Delete(containerID []byte, signature interop.Signature, token []byte) {
 		}
 
 		common.RemoveVotes(ctx, id)
+		contract.Call(balanceContractAddr, "unlockFee", contract.All, escrowID)
 	} else {
 		multiaddr := common.AlphabetAddress()
 		common.CheckAlphabetWitness(multiaddr)
 	}
 
-	key := append([]byte(nnsHasAliasKey), containerID...)
-	domain := storage.Get(ctx, key).(string)
-	if len(domain) != 0 {
-		storage.Delete(ctx, key)
-		// We should do `getRecord` first because NNS record could be deleted
-		// by other means (expiration, manual), thus leading to failing `deleteRecord`
-		// and inability to delete a container. We should also check if we own the record in case.
-		nnsContractAddr := storage.Get(ctx, nnsContractKey).(interop.Hash160)
-		res := contract.Call(nnsContractAddr, "getRecords", contract.ReadStates|contract.AllowCall, domain, 16 /* TXT */)
-		if res != nil && std.Base58Encode(containerID) == string(res.([]interface{})[0].(string)) {
-			contract.Call(nnsContractAddr, "deleteRecords", contract.All, domain, 16 /* TXT */)
-		}
-	}
-	removeContainer(ctx, containerID, ownerID)
-	runtime.Log("remove container")
-	runtime.Notify("DeleteSuccess", containerID)
+	transferFee(netmapContractAddr, balanceContractAddr, from, alphabet, aliasFee, containerID)
+
+	removeAlias(ctx, containerID)
+	addAlias(ctx, nnsContractAddr, containerID, domain, needRegister)
+
+	runtime.Log("container alias has been set")
+	runtime.Notify("SetAliasSuccess", containerID, domain)
 }
 
-// Get method returns a structure that contains a stable marshaled Container structure,
-// the signature, the public key of the container creator and a stable marshaled SessionToken
-// structure if it was provided.
+// RemoveAlias method removes the nice name of the container if it was invoked
+// by Alphabet nodes of the Inner Ring. Otherwise, it produces removeAlias
+// notification.
+//
+// Signature is a RFC6979 signature of the container ID.
+// Token is optional and should be a stable marshaled SessionToken structure from
+// API.
 //
 // If the container doesn't exist, it panics with NotFoundError.
-func Get(containerID []byte) Container {
-	ctx := storage.GetReadOnlyContext()
-	cnt := getContainer(ctx, containerID)
-	if len(cnt.value) == 0 {
+func RemoveAlias(containerID []byte, signature interop.Signature, token []byte) {
+	ctx := storage.GetContext()
+	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
+
+	if getOwnerByID(ctx, containerID) == nil {
 		panic(NotFoundError)
 	}
-	return cnt
+	if storage.Get(ctx, append([]byte(nnsHasAliasKey), containerID...)) == nil {
+		panic("container has no alias")
+	}
+
+	if notaryDisabled {
+		alphabet := common.AlphabetNodes()
+		nodeKey := common.InnerRingInvoker(alphabet)
+		if len(nodeKey) == 0 {
+			runtime.Notify("removeAlias", containerID, signature, token)
+			return
+		}
+
+		threshold := len(alphabet)*2/3 + 1
+		id := common.InvokeID([]interface{}{containerID, signature}, []byte("removeAlias"))
+
+		n := common.Vote(ctx, id, nodeKey)
+		if n < threshold {
+			return
+		}
+
+		common.RemoveVotes(ctx, id)
+	} else {
+		multiaddr := common.AlphabetAddress()
+		common.CheckAlphabetWitness(multiaddr)
+	}
+
+	domain := removeAlias(ctx, containerID)
+
+	runtime.Log("container alias has been removed")
+	runtime.Notify("RemoveAliasSuccess", containerID, domain)
 }
 
-// Owner method returns a 25 byte Owner ID of the container.
+// Alias method returns the nice name (NNS domain) of the container. Empty string
+// is returned if the container has no alias.
 //
 // If the container doesn't exist, it panics with NotFoundError.
-func Owner(containerID []byte) []byte {
+func Alias(containerID []byte) string {
 	ctx := storage.GetReadOnlyContext()
-	owner := getOwnerByID(ctx, containerID)
-	if owner == nil {
+	if getOwnerByID(ctx, containerID) == nil {
 		panic(NotFoundError)
 	}
-	return owner
-}
 
-// Count method returns the number of registered containers.
-func Count() int {
-	count := 0
-	ctx := storage.GetReadOnlyContext()
-	it := storage.Find(ctx, []byte{containerKeyPrefix}, storage.KeysOnly)
-	for iterator.Next(it) {
-		count++
+	domain := storage.Get(ctx, append([]byte(nnsHasAliasKey), containerID...))
+	if domain == nil {
+		return ""
 	}
-	return count
+	return domain.(string)
 }
 
-// ContainersOf iterates over all container IDs owned by the specified owner.
-// If owner is nil, it iterates over all containers.
-func ContainersOf(owner []byte) iterator.Iterator {
+// ContainerByName method returns the ID of the container with the specified
+// nice name (NNS domain), see PutNamed and SetAlias. Unlike NNS TXT records,
+// the result is maintained by the contract itself.
+//
+// If there is no container with the specified name, it panics with NotFoundError.
+func ContainerByName(domain string) []byte {
 	ctx := storage.GetReadOnlyContext()
-	key := []byte{ownerKeyPrefix}
-	if len(owner) != 0 {
-		key = append(key, owner...)
+	id := storage.Get(ctx, nameIndexKey(domain))
+	if id == nil {
+		panic(NotFoundError)
 	}
-	return storage.Find(ctx, key, storage.ValuesOnly)
+	return id.([]byte)
 }
 
-// List method returns a list of all container IDs owned by the specified owner.
-func List(owner []byte) [][]byte {
-	ctx := storage.GetReadOnlyContext()
+// TransferOwnership method moves the container to the new owner if it was
+// invoked by Alphabet nodes of the Inner Ring. Otherwise, it produces
+// transferOwnership notification.
+//
+// NewOwner is a 25 byte Owner ID of the new container owner.
+// Signature is a RFC6979 signature of the container ID and the new owner ID.
+// PublicKey contains the public key of the signer, it must belong to the
+// current owner or be bound to it in FrostFSID contract.
+// Token is optional and should be a stable marshaled SessionToken structure from
+// API.
+//
+// If the container doesn't exist, it panics with NotFoundError. If the public
+// key doesn't belong to the owner, it panics with InvalidOwnerKeyError.
+func TransferOwnership(containerID, newOwner []byte, signature interop.Signature, publicKey interop.PublicKey, token []byte) {
+	ctx := storage.GetContext()
+	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
 
-	if len(owner) == 0 {
-		return getAllContainers(ctx)
+	if len(newOwner) != ownerSize {
+		panic("incorrect owner")
 	}
 
-	var list [][]byte
+	ownerID := getOwnerByID(ctx, containerID)
+	if ownerID == nil {
+		panic(NotFoundError)
+	}
+	if common.BytesEqual(ownerID, newOwner) {
+		panic("container is already owned by the specified owner")
+	}
+	checkOwnerKey(ctx, ownerID, publicKey)
+
+	if notaryDisabled {
+		alphabet := common.AlphabetNodes()
+		nodeKey := common.InnerRingInvoker(alphabet)
+		if len(nodeKey) == 0 {
+			runtime.Notify("transferOwnership", containerID, newOwner, signature, publicKey, token)
+			return
+		}
+
+		threshold := len(alphabet)*2/3 + 1
+		id := common.InvokeID([]interface{}{containerID, newOwner, signature}, []byte("transferOwnership"))
+
+		n := common.Vote(ctx, id, nodeKey)
+		if n < threshold {
+			return
+		}
+
+		common.RemoveVotes(ctx, id)
+	} else {
+		multiaddr := common.AlphabetAddress()
+		common.CheckAlphabetWitness(multiaddr)
+	}
+
+	oldKey := append([]byte{ownerKeyPrefix}, ownerID...)
+	storage.Delete(ctx, append(oldKey, containerID...))
+	newKey := append([]byte{ownerKeyPrefix}, newOwner...)
+	storage.Put(ctx, append(newKey, containerID...), containerID)
+
+	updateCounter(ctx, append([]byte{ownerCountKeyPrefix}, ownerID...), -1)
+	updateCounter(ctx, append([]byte{ownerCountKeyPrefix}, newOwner...), 1)
+
+	storage.Put(ctx, append([]byte{ownerOverridePrefix}, containerID...), newOwner)
+
+	runtime.Log("container ownership transferred")
+	runtime.Notify("TransferOwnershipSuccess", containerID, ownerID, newOwner)
+}
+
+// Delete method removes a container from the contract storage if it has been
+// invoked by Alphabet nodes of the Inner Ring. Otherwise, it produces
+// containerDelete notification. Container fees are paid out when the container
+// is created, so they are not refunded, even partially, on deletion.
+//
+// Signature is a RFC6979 signature of the container ID.
+// Token is optional and should be a stable marshaled SessionToken structure from
+// API.
+//
+// If the container doesn't exist, it panics with NotFoundError.
+func Delete(containerID []byte, signature interop.Signature, token []byte) {
+	ctx := storage.GetContext()
+	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
+
+	ownerID := getOwnerByID(ctx, containerID)
+	if ownerID == nil {
+		return
+	}
+
+	if notaryDisabled {
+		alphabet := common.AlphabetNodes()
+		nodeKey := common.InnerRingInvoker(alphabet)
+		if len(nodeKey) == 0 {
+			runtime.Notify("containerDelete", containerID, signature, token)
+			return
+		}
+
+		threshold := len(alphabet)*2/3 + 1
+		id := common.InvokeID([]interface{}{containerID, signature}, []byte("delete"))
+
+		n := common.Vote(ctx, id, nodeKey)
+		if n < threshold {
+			return
+		}
+
+		common.RemoveVotes(ctx, id)
+	} else {
+		multiaddr := common.AlphabetAddress()
+		common.CheckAlphabetWitness(multiaddr)
+	}
+
+	removeAlias(ctx, containerID)
+	removeContainer(ctx, containerID, ownerID)
+	markContainerDeleted(ctx, containerID, ownerID)
+	runtime.Log("remove container")
+	runtime.Notify("DeleteSuccess", containerID)
+}
+
+// DeletionInfo method returns the owner of the deleted container and the epoch
+// when the container was deleted. Information about deleted containers is
+// stored for the number of epochs specified in TombstoneRetentionKey netmap
+// config (DefaultTombstoneRetention by default) at the deletion time.
+//
+// If the container has not been deleted or information about it has already
+// been removed, it panics with NotFoundError.
+func DeletionInfo(containerID []byte) DelInfo {
+	ctx := storage.GetReadOnlyContext()
+	data := storage.Get(ctx, append([]byte{deletedKeyPrefix}, containerID...))
+	if data == nil {
+		panic(NotFoundError)
+	}
+	return std.Deserialize(data.([]byte)).(DelInfo)
+}
+
+// DeletedContainers method returns iterator over IDs of the containers
+// deleted in the specified epoch. See DeletionInfo for retention details.
+func DeletedContainers(epoch int) iterator.Iterator {
+	ctx := storage.GetReadOnlyContext()
+	key := append([]byte{deletedEpochPrefix}, epochKey(epoch)...)
+	return storage.Find(ctx, key, storage.ValuesOnly)
+}
+
+// Get method returns a structure that contains a stable marshaled Container structure,
+// the signature, the public key of the container creator and a stable marshaled SessionToken
+// structure if it was provided.
+//
+// If the container doesn't exist, it panics with NotFoundError.
+func Get(containerID []byte) Container {
+	ctx := storage.GetReadOnlyContext()
+	cnt := getContainer(ctx, containerID)
+	if len(cnt.value) == 0 {
+		panic(NotFoundError)
+	}
+	return cnt
+}
+
+// Owner method returns a 25 byte Owner ID of the container.
+//
+// If the container doesn't exist, it panics with NotFoundError.
+func Owner(containerID []byte) []byte {
+	ctx := storage.GetReadOnlyContext()
+	owner := getOwnerByID(ctx, containerID)
+	if owner == nil {
+		panic(NotFoundError)
+	}
+	return owner
+}
+
+// Count method returns the number of registered containers.
+func Count() int {
+	ctx := storage.GetReadOnlyContext()
+	return getCounter(ctx, containerCountKey)
+}
+
+// CountByOwner method returns the number of containers owned by the specified
+// owner.
+func CountByOwner(owner []byte) int {
+	ctx := storage.GetReadOnlyContext()
+	return getCounter(ctx, append([]byte{ownerCountKeyPrefix}, owner...))
+}
+
+// ContainersOf iterates over all container IDs owned by the specified owner.
+// If owner is nil, it iterates over all containers.
+func ContainersOf(owner []byte) iterator.Iterator {
+	ctx := storage.GetReadOnlyContext()
+	key := []byte{ownerKeyPrefix}
+	if len(owner) != 0 {
+		key = append(key, owner...)
+	}
+	return storage.Find(ctx, key, storage.ValuesOnly)
+}
+
+// ContainersByAttribute iterates over all IDs of containers which have an
+// attribute with the specified key and value. Only attributes with the keys
+// listed in IndexedAttributesKey netmap config (DefaultIndexedAttributes by
+// default) at the container creation time are indexed.
+func ContainersByAttribute(key, value string) iterator.Iterator {
+	ctx := storage.GetReadOnlyContext()
+	return storage.Find(ctx, attributeIndexKey([]byte(key), []byte(value)), storage.ValuesOnly)
+}
+
+// ContainersOfPaged returns at most limit container IDs owned by the specified
+// owner, which go after the cursor in the lexicographical order of IDs. If owner
+// is nil, it pages over all containers. Empty cursor means the first page.
+//
+// To list all containers, the last returned ID should be passed as a cursor
+// of the next call until less than limit IDs are returned. The cost of the
+// call doesn't depend on the cursor position.
+//
+// Limit MUST be positive and MUST NOT be greater than MaxPageSize.
+func ContainersOfPaged(owner []byte, cursor []byte, limit int) [][]byte {
+	if limit <= 0 || limit > MaxPageSize {
+		panic("invalid page size")
+	}
+
+	ctx := storage.GetReadOnlyContext()
+	prefix := []byte{containerKeyPrefix}
+	count := getCounter(ctx, containerCountKey)
+	if len(owner) != 0 {
+		prefix = append([]byte{ownerKeyPrefix}, owner...)
+		count = getCounter(ctx, append([]byte{ownerCountKeyPrefix}, owner...))
+	}
+
+	list := [][]byte{}
+	if len(cursor) == 0 {
+		return appendPage(ctx, prefix, prefix, nil, list, limit)
+	}
+
+	// Container IDs are hashes, so they are uniformly distributed. Choose
+	// the number of leading cursor bytes, such that there are few IDs starting
+	// with them, and look for the cursor there.
+	depth := 0
+	for n := count; n > 256 && depth < len(cursor); n /= 256 {
+		depth++
+	}
+
+	list = appendPage(ctx, prefix, append(prefix, cursor[:depth]...), cursor, list, limit)
+
+	// Then go through the subsequent ranges of IDs from the narrowest one.
+	for i := depth - 1; i >= 0 && len(list) < limit; i-- {
+		base := append(prefix, cursor[:i]...)
+		for b := int(cursor[i]) + 1; b < 256 && len(list) < limit; b++ {
+			list = appendPage(ctx, prefix, append(base, byte(b)), nil, list, limit)
+		}
+	}
+
+	return list
+}
+
+// appendPage appends IDs of the containers stored under the key to the list
+// until its size reaches the limit. IDs are trimmed of the prefix, IDs which
+// are not greater than the cursor are skipped.
+func appendPage(ctx storage.Context, prefix, key, cursor []byte, list [][]byte, limit int) [][]byte {
+	it := storage.Find(ctx, key, storage.KeysOnly)
+	for len(list) < limit && iterator.Next(it) {
+		id := iterator.Value(it).([]byte)[len(prefix):]
+		if len(cursor) != 0 && std.MemoryCompare(id, cursor) <= 0 {
+			continue
+		}
+
+		list = append(list, id)
+	}
+
+	return list
+}
+
+// List method returns a list of all container IDs owned by the specified owner.
+//
+// The whole list is built in a single invocation, so ContainersOf or
+// ContainersOfPaged should be used for owners with a lot of containers.
+func List(owner []byte) [][]byte {
+	ctx := storage.GetReadOnlyContext()
+
+	if len(owner) == 0 {
+		return getAllContainers(ctx)
+	}
+
+	var list [][]byte
 
 	it := storage.Find(ctx, append([]byte{ownerKeyPrefix}, owner...), storage.ValuesOnly)
 	for iterator.Next(it) {
@@ -508,6 +1204,7 @@ func SetEACL(eACL []byte, signature interop.Signature, publicKey interop.PublicK
 	key := append(eACLPrefix, containerID...)
 
 	common.SetSerialized(ctx, key, rule)
+	addEACLHistoryItem(ctx, containerID, rule)
 
 	runtime.Log("success")
 	runtime.Notify("SetEACLSuccess", containerID, publicKey)
@@ -529,10 +1226,125 @@ func EACL(containerID []byte) ExtendedACL {
 	return getEACL(ctx, containerID)
 }
 
+// EACLHistory method returns an iterator over the last MaxEACLHistorySize
+// extended ACL changes of the container. Values are EACLHistoryItem structures
+// ordered by the block height. If the extended ACL was changed several times
+// within a single block, only the last change is stored.
+func EACLHistory(containerID []byte) iterator.Iterator {
+	ctx := storage.GetReadOnlyContext()
+	key := append([]byte{eACLHistoryPrefix}, containerID...)
+	return storage.Find(ctx, key, storage.ValuesOnly|storage.DeserializeValues)
+}
+
+// EACLAt method returns the extended ACL of the container which was actual
+// at the specified epoch, i.e. the last one set no later than the epoch.
+// Empty structure is returned if there is no such extended ACL in the history.
+//
+// If the container doesn't exist, it panics with NotFoundError.
+func EACLAt(containerID []byte, epoch int) ExtendedACL {
+	ctx := storage.GetReadOnlyContext()
+
+	ownerID := getOwnerByID(ctx, containerID)
+	if ownerID == nil {
+		panic(NotFoundError)
+	}
+
+	rule := ExtendedACL{value: []byte{}, sig: interop.Signature{}, pub: interop.PublicKey{}, token: []byte{}}
+
+	key := append([]byte{eACLHistoryPrefix}, containerID...)
+	it := storage.Find(ctx, key, storage.ValuesOnly|storage.DeserializeValues)
+	for iterator.Next(it) {
+		item := iterator.Value(it).(EACLHistoryItem)
+		if item.Epoch > epoch {
+			break
+		}
+		rule = item.EACL
+	}
+
+	return rule
+}
+
+// SetQuota method sets the size limit of the container or of all containers
+// of the owner if it was invoked by Alphabet nodes of the Inner Ring.
+// Otherwise, it produces setQuota notification.
+//
+// ID is either a 32 byte container ID or a 25 byte owner ID. Limit is the size
+// limit in bytes, zero limit removes the quota.
+// Signature is a RFC6979 signature of the ID and the limit.
+// PublicKey contains the public key of the signer, it must belong to the owner
+// or be bound to it in FrostFSID contract.
+// Token is optional and should be a stable marshaled SessionToken structure from
+// API.
+//
+// If the container doesn't exist, it panics with NotFoundError.
+func SetQuota(id []byte, limit int, signature interop.Signature, publicKey interop.PublicKey, token []byte) {
+	ctx := storage.GetContext()
+	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
+
+	if limit < 0 {
+		panic("invalid quota limit")
+	}
+
+	ownerID := id
+	if len(id) == containerIDSize {
+		ownerID = getOwnerByID(ctx, id)
+		if ownerID == nil {
+			panic(NotFoundError)
+		}
+	} else if len(id) != ownerSize {
+		panic("incorrect container or owner ID")
+	}
+	checkOwnerKey(ctx, ownerID, publicKey)
+
+	if notaryDisabled {
+		alphabet := common.AlphabetNodes()
+		nodeKey := common.InnerRingInvoker(alphabet)
+		if len(nodeKey) == 0 {
+			runtime.Notify("setQuota", id, limit, signature, publicKey, token)
+			return
+		}
+
+		threshold := len(alphabet)*2/3 + 1
+		voteID := common.InvokeID([]interface{}{id, limit, signature}, []byte("setQuota"))
+
+		n := common.Vote(ctx, voteID, nodeKey)
+		if n < threshold {
+			return
+		}
+
+		common.RemoveVotes(ctx, voteID)
+	} else {
+		multiaddr := common.AlphabetAddress()
+		common.CheckAlphabetWitness(multiaddr)
+	}
+
+	key := append([]byte{quotaKeyPrefix}, id...)
+	if limit == 0 {
+		storage.Delete(ctx, key)
+	} else {
+		storage.Put(ctx, key, limit)
+	}
+
+	runtime.Log("quota has been set")
+	runtime.Notify("SetQuotaSuccess", id, limit)
+}
+
+// Quota method returns the size limit in bytes of the container or of all
+// containers of the owner depending on ID length, see SetQuota. Container limit
+// defaults to the value from netmap config (DefaultQuotaKey). Zero means no
+// limit.
+func Quota(id []byte) int {
+	ctx := storage.GetReadOnlyContext()
+	return getQuota(ctx, id)
+}
+
 // PutContainerSize method saves container size estimation in contract
 // memory. It can be invoked only by Storage nodes from the network map. This method
 // checks witness based on the provided public key of the Storage node.
 //
+// When the median of container size estimations for the epoch crosses the
+// container quota, QuotaExceeded notification is produced.
+//
 // If the container doesn't exist, it panics with NotFoundError.
 func PutContainerSize(epoch int, cid []byte, usedSize int, pubKey interop.PublicKey) {
 	ctx := storage.GetContext()
@@ -547,17 +1359,37 @@ func PutContainerSize(epoch int, cid []byte, usedSize int, pubKey interop.Public
 		panic("method must be invoked by storage node from network map")
 	}
 
-	key := estimationKey(epoch, cid, pubKey)
+	putContainerSize(ctx, epoch, cid, usedSize, pubKey, getDefaultQuota(ctx))
 
-	s := estimation{
-		from: pubKey,
-		size: usedSize,
+	runtime.Log("saved container size estimation")
+}
+
+// PutContainerSizes method is similar to PutContainerSize, but saves
+// estimations of multiple containers in a single call. Each element of sizes
+// is a pair of container ID and its estimated size. Witness and network map
+// membership are checked once, so it is cheaper than calling
+// PutContainerSize for each container.
+//
+// If any of the containers doesn't exist, it panics with NotFoundError.
+func PutContainerSizes(epoch int, sizes []ContainerSize, pubKey interop.PublicKey) {
+	ctx := storage.GetContext()
+
+	common.CheckWitness(pubKey)
+
+	if !isStorageNode(ctx, pubKey) {
+		panic("method must be invoked by storage node from network map")
 	}
 
-	storage.Put(ctx, key, std.Serialize(s))
-	updateEstimations(ctx, epoch, cid, pubKey, false)
+	defaultQuota := getDefaultQuota(ctx)
+	for i := range sizes {
+		if getOwnerByID(ctx, sizes[i].CID) == nil {
+			panic(NotFoundError)
+		}
 
-	runtime.Log("saved container size estimation")
+		putContainerSize(ctx, epoch, sizes[i].CID, sizes[i].Size, pubKey, defaultQuota)
+	}
+
+	runtime.Log("saved container size estimations")
 }
 
 // GetContainerSize method returns the container ID and a slice of container
@@ -579,6 +1411,15 @@ func GetContainerSize(id []byte) containerSizes {
 	return getContainerSizeEstimation(ctx, id, cid)
 }
 
+// ContainerSizeSummary method returns aggregated size estimations of the
+// container for the specified epoch. Summary is updated on every
+// PutContainerSize call and removed along with the estimations, see NewEpoch
+// method. If there are no estimations, summary with zero values is returned.
+func ContainerSizeSummary(epoch int, cid []byte) SizeSummary {
+	ctx := storage.GetReadOnlyContext()
+	return getSizeSummary(ctx, epoch, cid)
+}
+
 // ListContainerSizes method returns the IDs of container size estimations
 // that have been registered for the specified epoch.
 func ListContainerSizes(epoch int) [][]byte {
@@ -624,8 +1465,10 @@ func IterateContainerSizes(epoch int) iterator.Iterator {
 	return storage.Find(ctx, key, storage.DeserializeValues)
 }
 
-// NewEpoch method removes all container size estimations from epoch older than
-// epochNum + 3. It can be invoked only by NewEpoch method of the Netmap contract.
+// NewEpoch method removes all container size estimations, their summaries and
+// owner usage from epoch older than epochNum + 3 and information about
+// containers deleted before the retention window. It can be invoked only by
+// NewEpoch method of the Netmap contract.
 func NewEpoch(epochNum int) {
 	ctx := storage.GetContext()
 	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
@@ -645,6 +1488,8 @@ func NewEpoch(epochNum int) {
 	}
 
 	cleanupContainers(ctx, epochNum)
+	cleanupSizeSummaries(ctx, epochNum)
+	cleanupDeletedContainers(ctx, epochNum)
 }
 
 // StartContainerEstimation method produces StartEstimation notification.
@@ -687,6 +1532,16 @@ func StartContainerEstimation(epoch int) {
 
 // StopContainerEstimation method produces StopEstimation notification.
 // It can be invoked only by Alphabet nodes of the Inner Ring.
+//
+// Epoch is the estimated epoch, the same one storage nodes pass to
+// PutContainerSize. Its estimations are final at this moment, so the sizes of
+// containers (see ContainerSizeSummary) in this epoch are summed per owner,
+// see OwnerUsage and ListOwnerUsage methods. OwnerQuotaExceeded notification
+// is produced for each owner whose total size exceeds the owner quota.
+//
+// All container size summaries of the epoch are processed in this single
+// invocation, so the number of containers with estimations in the epoch is
+// limited by the GAS the Inner Ring spends on the transaction.
 func StopContainerEstimation(epoch int) {
 	ctx := storage.GetContext()
 	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
@@ -719,13 +1574,38 @@ func StopContainerEstimation(epoch int) {
 		common.RemoveVotes(ctx, id)
 	}
 
+	updateOwnerUsage(ctx, epoch)
+
 	runtime.Notify("StopEstimation", epoch)
 	runtime.Log("notification has been produced")
 }
 
-// Version returns the version of the contract.
-func Version() int {
-	return common.Version
+// OwnerUsage method returns the total size of containers owned by the specified
+// owner in the specified epoch. Usage is calculated by StopContainerEstimation
+// method of the next epoch and removed along with the estimations, see
+// NewEpoch method.
+func OwnerUsage(owner []byte, epoch int) int {
+	ctx := storage.GetReadOnlyContext()
+
+	data := storage.Get(ctx, ownerUsageKey(epoch, owner))
+	if data != nil {
+		return std.Deserialize(data.([]byte)).(UsageInfo).Size
+	}
+
+	return 0
+}
+
+// ListOwnerUsage method returns an iterator over UsageInfo structures of all
+// owners for the specified epoch.
+func ListOwnerUsage(epoch int) iterator.Iterator {
+	ctx := storage.GetReadOnlyContext()
+	key := append([]byte{ownerUsagePrefix}, epochKey(epoch)...)
+	return storage.Find(ctx, key, storage.ValuesOnly|storage.DeserializeValues)
+}
+
+// Version returns the version of the contract.
+func Version() int {
+	return common.Version
 }
 
 func addContainer(ctx storage.Context, id, owner []byte, container Container) {
@@ -735,6 +1615,11 @@ func addContainer(ctx storage.Context, id, owner []byte, container Container) {
 
 	idKey := append([]byte{containerKeyPrefix}, id...)
 	common.SetSerialized(ctx, idKey, container)
+
+	putAttributeIndex(ctx, id, container.value, indexedAttributes(ctx))
+
+	updateCounter(ctx, containerCountKey, 1)
+	updateCounter(ctx, append([]byte{ownerCountKeyPrefix}, owner...), 1)
 }
 
 func removeContainer(ctx storage.Context, id []byte, owner []byte) {
@@ -742,7 +1627,266 @@ func removeContainer(ctx storage.Context, id []byte, owner []byte) {
 	containerListKey = append(containerListKey, id...)
 	storage.Delete(ctx, containerListKey)
 
+	cnr := getContainer(ctx, id)
+	attrs := attributesFromBinaryContainer(cnr.value)
+	for i := range attrs {
+		storage.Delete(ctx, append(attributeIndexKey(attrs[i].key, attrs[i].value), id...))
+	}
+
 	storage.Delete(ctx, append([]byte{containerKeyPrefix}, id...))
+	storage.Delete(ctx, append([]byte{ownerOverridePrefix}, id...))
+	storage.Delete(ctx, append([]byte{quotaKeyPrefix}, id...))
+	storage.Delete(ctx, append(eACLPrefix, id...))
+	removeEACLHistory(ctx, id)
+	removeEstimations(ctx, id)
+
+	updateCounter(ctx, containerCountKey, -1)
+	updateCounter(ctx, append([]byte{ownerCountKeyPrefix}, owner...), -1)
+}
+
+// addEACLHistoryItem appends the extended ACL to the history of the container
+// and removes the oldest items if the history is too long.
+func addEACLHistoryItem(ctx storage.Context, id []byte, rule ExtendedACL) {
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	epoch := contract.Call(netmapContractAddr, "epoch", contract.ReadOnly).(int)
+	height := ledger.CurrentIndex()
+
+	prefix := append([]byte{eACLHistoryPrefix}, id...)
+	common.SetSerialized(ctx, append(prefix, heightKey(height)...), EACLHistoryItem{
+		EACL:   rule,
+		Epoch:  epoch,
+		Height: height,
+	})
+
+	var keys [][]byte
+	it := storage.Find(ctx, prefix, storage.KeysOnly)
+	for iterator.Next(it) {
+		keys = append(keys, iterator.Value(it).([]byte))
+	}
+
+	for i := 0; i < len(keys)-MaxEACLHistorySize; i++ {
+		storage.Delete(ctx, keys[i])
+	}
+}
+
+func removeEACLHistory(ctx storage.Context, id []byte) {
+	it := storage.Find(ctx, append([]byte{eACLHistoryPrefix}, id...), storage.KeysOnly)
+	for iterator.Next(it) {
+		storage.Delete(ctx, iterator.Value(it).([]byte))
+	}
+}
+
+// markContainerDeleted stores information about the deleted container
+// along with the current epoch. Information is removed in the epoch
+// following the retention period which is set at the deletion time.
+func markContainerDeleted(ctx storage.Context, id, owner []byte) {
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	epoch := contract.Call(netmapContractAddr, "epoch", contract.ReadOnly).(int)
+
+	retention := DefaultTombstoneRetention
+	value := netmapConfig(netmapContractAddr, TombstoneRetentionKey)
+	if value != nil {
+		retention = value.(int)
+	}
+
+	key := append([]byte{deletedKeyPrefix}, id...)
+	common.SetSerialized(ctx, key, DelInfo{
+		Owner: owner,
+		Epoch: epoch,
+	})
+	storage.Put(ctx, deletedEpochKey(epoch, id), id)
+	storage.Put(ctx, tombstoneExpiryKey(epoch+retention+1, id), id)
+}
+
+// cleanupDeletedContainers removes information about deleted containers
+// which retention period ends in the specified epoch.
+func cleanupDeletedContainers(ctx storage.Context, epoch int) {
+	key := append([]byte{tombstoneExpiryPrefix}, epochKey(epoch)...)
+	it := storage.Find(ctx, key, storage.ValuesOnly)
+	for iterator.Next(it) {
+		id := iterator.Value(it).([]byte)
+
+		infoKey := append([]byte{deletedKeyPrefix}, id...)
+		data := storage.Get(ctx, infoKey)
+		if data != nil {
+			info := std.Deserialize(data.([]byte)).(DelInfo)
+			storage.Delete(ctx, deletedEpochKey(info.Epoch, id))
+			storage.Delete(ctx, infoKey)
+		}
+
+		storage.Delete(ctx, tombstoneExpiryKey(epoch, id))
+	}
+}
+
+func deletedEpochKey(epoch int, id []byte) []byte {
+	key := append([]byte{deletedEpochPrefix}, epochKey(epoch)...)
+	return append(key, id...)
+}
+
+func tombstoneExpiryKey(epoch int, id []byte) []byte {
+	key := append([]byte{tombstoneExpiryPrefix}, epochKey(epoch)...)
+	return append(key, id...)
+}
+
+// epochKey returns fixed-size little-endian representation of the epoch,
+// so that storage keys of different epochs never share a prefix.
+func epochKey(epoch int) []byte {
+	key := convert.ToBytes(epoch)
+	for len(key) < epochKeySize {
+		key = append(key, 0)
+	}
+	return key
+}
+
+// heightKey returns fixed-size big-endian representation of the block height,
+// so that storage keys are ordered by the height.
+func heightKey(height int) []byte {
+	le := epochKey(height)
+	key := make([]byte, len(le))
+	for i := range le {
+		key[len(le)-1-i] = le[i]
+	}
+	return key
+}
+
+func getCounter(ctx storage.Context, key interface{}) int {
+	data := storage.Get(ctx, key)
+	if data != nil {
+		return data.(int)
+	}
+
+	return 0
+}
+
+// updateCounter adds delta to the counter stored by the key. Counter is removed
+// from the storage when it reaches zero.
+func updateCounter(ctx storage.Context, key interface{}, delta int) {
+	count := getCounter(ctx, key) + delta
+	if count <= 0 {
+		storage.Delete(ctx, key)
+		return
+	}
+
+	storage.Put(ctx, key, count)
+}
+
+// isMigrated returns true if the one-time migration with the specified name
+// has already been performed.
+func isMigrated(ctx storage.Context, name string) bool {
+	return storage.Get(ctx, append([]byte{migrationKeyPrefix}, []byte(name)...)) != nil
+}
+
+// setMigrated marks the one-time migration with the specified name as performed.
+func setMigrated(ctx storage.Context, name string) {
+	storage.Put(ctx, append([]byte{migrationKeyPrefix}, []byte(name)...), true)
+}
+
+// initContainerCounters calculates total and per-owner container counters
+// from the owner-cid map. It is used to migrate contracts which have been
+// deployed before counters were introduced.
+func initContainerCounters(ctx storage.Context) {
+	var (
+		total int
+		owner []byte
+		count int
+	)
+
+	// Keys are sorted, so containers of the same owner go one after another.
+	it := storage.Find(ctx, []byte{ownerKeyPrefix}, storage.KeysOnly|storage.RemovePrefix)
+	for iterator.Next(it) {
+		key := iterator.Value(it).([]byte)
+		keyOwner := key[:len(key)-containerIDSize]
+		if count != 0 && !common.BytesEqual(owner, keyOwner) {
+			storage.Put(ctx, append([]byte{ownerCountKeyPrefix}, owner...), count)
+			count = 0
+		}
+
+		owner = keyOwner
+		count++
+		total++
+	}
+
+	if count != 0 {
+		storage.Put(ctx, append([]byte{ownerCountKeyPrefix}, owner...), count)
+	}
+	if total != 0 {
+		storage.Put(ctx, containerCountKey, total)
+	}
+}
+
+// initAttributeIndex indexes attributes of all existing containers.
+func initAttributeIndex(ctx storage.Context) {
+	indexed := indexedAttributes(ctx)
+
+	it := storage.Find(ctx, []byte{containerKeyPrefix}, storage.RemovePrefix)
+	for iterator.Next(it) {
+		item := iterator.Value(it).(struct {
+			key   []byte
+			value []byte
+		})
+		cnr := std.Deserialize(item.value).(Container)
+		putAttributeIndex(ctx, item.key, cnr.value, indexed)
+	}
+}
+
+// initNameIndex indexes existing containers by their nice names.
+func initNameIndex(ctx storage.Context) {
+	it := storage.Find(ctx, []byte(nnsHasAliasKey), storage.RemovePrefix)
+	for iterator.Next(it) {
+		item := iterator.Value(it).(struct {
+			key   []byte
+			value []byte
+		})
+		storage.Put(ctx, nameIndexKey(string(item.value)), item.key)
+	}
+}
+
+// nameIndexKey returns the key of the index of containers by nice names.
+// Domain is hashed, so that storage key size limit is not exceeded.
+func nameIndexKey(domain string) []byte {
+	return append([]byte{nameKeyPrefix}, crypto.Ripemd160([]byte(domain))...)
+}
+
+// netmapConfig returns the value of netmap configuration converted to the type
+// from the configuration schema or nil if the value is missing.
+func netmapConfig(netmapContractAddr interop.Hash160, key string) interface{} {
+	return contract.Call(netmapContractAddr, "configTyped", contract.ReadOnly, key)
+}
+
+// indexedAttributes returns keys of container attributes which are indexed.
+func indexedAttributes(ctx storage.Context) []string {
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	value := netmapConfig(netmapContractAddr, IndexedAttributesKey)
+	if value == nil {
+		return std.StringSplit(DefaultIndexedAttributes, ",")
+	}
+
+	return std.StringSplit(value.(string), ",")
+}
+
+// putAttributeIndex indexes the container by its attributes with the keys
+// from the indexed list. Index records are removed by removeContainer
+// regardless of the list, so it can be changed at any time.
+func putAttributeIndex(ctx storage.Context, id, container []byte, indexed []string) {
+	attrs := attributesFromBinaryContainer(container)
+	for i := range attrs {
+		for j := range indexed {
+			if string(attrs[i].key) == indexed[j] {
+				storage.Put(ctx, append(attributeIndexKey(attrs[i].key, attrs[i].value), id...), id)
+				break
+			}
+		}
+	}
+}
+
+// attributeIndexKey returns the prefix of the index of containers with the
+// specified attribute. Key and value are hashed, so that storage key size
+// limit is not exceeded.
+func attributeIndexKey(key, value []byte) []byte {
+	data := append([]byte{}, key...)
+	data = append(data, 0)
+	data = append(data, value...)
+	return append([]byte{attributeKeyPrefix}, crypto.Ripemd160(data)...)
 }
 
 func getAllContainers(ctx storage.Context) [][]byte {
@@ -777,7 +1921,14 @@ func getContainer(ctx storage.Context, cid []byte) Container {
 	return Container{value: []byte{}, sig: interop.Signature{}, pub: interop.PublicKey{}, token: []byte{}}
 }
 
+// getOwnerByID returns the current owner of the container. The owner is
+// taken from the container structure unless the ownership has been transferred.
 func getOwnerByID(ctx storage.Context, cid []byte) []byte {
+	owner := storage.Get(ctx, append([]byte{ownerOverridePrefix}, cid...))
+	if owner != nil {
+		return owner.([]byte)
+	}
+
 	container := getContainer(ctx, cid)
 	if len(container.value) == 0 {
 		return nil
@@ -786,6 +1937,50 @@ func getOwnerByID(ctx storage.Context, cid []byte) []byte {
 	return ownerFromBinaryContainer(container.value)
 }
 
+// checkOwnerKey panics with InvalidOwnerKeyError if the public key is neither
+// the key of the owner account nor bound to the owner in FrostFSID contract.
+func checkOwnerKey(ctx storage.Context, owner []byte, publicKey interop.PublicKey) {
+	if common.BytesEqual(common.WalletToScriptHash(owner), contract.CreateStandardAccount(publicKey)) {
+		return
+	}
+
+	frostfsIDContractAddr := storage.Get(ctx, frostfsIDContractKey).(interop.Hash160)
+	keys := contract.Call(frostfsIDContractAddr, "key", contract.ReadOnly, owner).([]interop.PublicKey)
+	for i := range keys {
+		if common.BytesEqual(keys[i], publicKey) {
+			return
+		}
+	}
+
+	panic(InvalidOwnerKeyError)
+}
+
+// checkPlacementPolicy panics if the placement policy of the container can't
+// be satisfied by the current network map or references a subnet which does
+// not exist. Containers without a placement policy are not checked.
+func checkPlacementPolicy(ctx storage.Context, container []byte) {
+	policy, ok := placementPolicyFromBinaryContainer(container)
+	if !ok {
+		return
+	}
+
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	nodes := contract.Call(netmapContractAddr, "netmap", contract.ReadOnly).([]interface{})
+	if policy.replicas > len(nodes) {
+		panic(NotEnoughNodesError)
+	}
+
+	if len(policy.subnet) != 0 {
+		subnetContractAddr := storage.Get(ctx, subnetContractKey)
+		if subnetContractAddr == nil {
+			panic(SubnetNotSupportedError)
+		}
+
+		// Subnet contract panics if the subnet does not exist.
+		contract.Call(subnetContractAddr.(interop.Hash160), "get", contract.ReadOnly, policy.subnet)
+	}
+}
+
 func ownerFromBinaryContainer(container []byte) []byte {
 	// V2 format
 	offset := int(container[1])
@@ -793,6 +1988,179 @@ func ownerFromBinaryContainer(container []byte) []byte {
 	return container[offset : offset+25] // offset + size of owner
 }
 
+// attributesFromBinaryContainer parses attributes of the stable marshaled
+// V2 container. Contract does not validate containers, so nil is returned
+// if the container can't be parsed.
+func attributesFromBinaryContainer(container []byte) []attribute {
+	var attrs []attribute
+
+	offset := 0
+	for offset < len(container) {
+		tag, _, start, next := readField(container, offset)
+		if next < 0 {
+			return nil
+		}
+
+		if tag == 5<<3|2 { // attributes field
+			a, ok := attributeFromBinary(container[start:next])
+			if !ok {
+				return nil
+			}
+			attrs = append(attrs, a)
+		}
+		offset = next
+	}
+
+	return attrs
+}
+
+func attributeFromBinary(data []byte) (attribute, bool) {
+	a := attribute{key: []byte{}, value: []byte{}}
+
+	offset := 0
+	for offset < len(data) {
+		tag, _, start, next := readField(data, offset)
+		if next < 0 || tag&7 != 2 {
+			return a, false
+		}
+
+		switch tag >> 3 {
+		case 1:
+			a.key = data[start:next]
+		case 2:
+			a.value = data[start:next]
+		}
+		offset = next
+	}
+
+	return a, true
+}
+
+// placementPolicyFromBinaryContainer parses the placement policy of the stable
+// marshaled V2 container. False is returned if the container can't be parsed
+// or has no placement policy.
+func placementPolicyFromBinaryContainer(container []byte) (placementPolicy, bool) {
+	policy := placementPolicy{subnet: []byte{}}
+
+	var data []byte
+	offset := 0
+	for offset < len(container) {
+		tag, _, start, next := readField(container, offset)
+		if next < 0 {
+			return policy, false
+		}
+
+		if tag == 6<<3|2 { // placement policy field
+			data = container[start:next]
+		}
+		offset = next
+	}
+
+	if data == nil {
+		return policy, false
+	}
+
+	offset = 0
+	for offset < len(data) {
+		tag, _, start, next := readField(data, offset)
+		if next < 0 {
+			return policy, false
+		}
+
+		switch tag {
+		case 1<<3 | 2: // replicas field
+			count, ok := replicaCountFromBinary(data[start:next])
+			if !ok {
+				return policy, false
+			}
+			if count > policy.replicas {
+				policy.replicas = count
+			}
+		case 5<<3 | 2: // subnet ID field
+			policy.subnet = data[start:next]
+		}
+		offset = next
+	}
+
+	return policy, true
+}
+
+func replicaCountFromBinary(data []byte) (int, bool) {
+	count := 0
+
+	offset := 0
+	for offset < len(data) {
+		tag, value, _, next := readField(data, offset)
+		if next < 0 {
+			return 0, false
+		}
+
+		if tag == 1<<3 { // count field
+			count = value
+		}
+		offset = next
+	}
+
+	return count, true
+}
+
+// readField reads protobuf field starting from the offset and returns its tag,
+// the value of varint field or the size of the other fields, the offset of the
+// field value and the offset of the next field. Negative offset of the next
+// field is returned if the data is malformed.
+func readField(data []byte, offset int) (int, int, int, int) {
+	tag, start := readVarint(data, offset)
+	if start < 0 {
+		return 0, 0, 0, -1
+	}
+
+	var size int
+	switch tag & 7 {
+	case 0: // varint
+		value, next := readVarint(data, start)
+		return tag, value, start, next
+	case 1: // 64-bit
+		size = 8
+	case 2: // length-delimited
+		size, start = readVarint(data, start)
+		if start < 0 {
+			return 0, 0, 0, -1
+		}
+	case 5: // 32-bit
+		size = 4
+	default:
+		return 0, 0, 0, -1
+	}
+
+	if size < 0 || start+size > len(data) {
+		return 0, 0, 0, -1
+	}
+
+	return tag, size, start, start + size
+}
+
+// readVarint reads protobuf varint starting from the offset and returns it
+// along with the offset of the next byte. Negative offset is returned if
+// the data is malformed.
+func readVarint(data []byte, offset int) (int, int) {
+	var (
+		value int
+		shift int
+	)
+
+	for offset < len(data) && shift < 64 {
+		b := int(data[offset])
+		offset++
+		value += (b & 0x7F) << shift
+		if b < 0x80 {
+			return value, offset
+		}
+		shift += 7
+	}
+
+	return 0, -1
+}
+
 func estimationKey(epoch int, cid []byte, key interop.PublicKey) []byte {
 	var buf interface{} = epoch
 
@@ -824,19 +2192,7 @@ func getContainerSizeEstimation(ctx storage.Context, key, cid []byte) containerS
 // announces container size estimation of the previous epoch.
 func isStorageNode(ctx storage.Context, key interop.PublicKey) bool {
 	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
-	snapshot := contract.Call(netmapContractAddr, "snapshot", contract.ReadOnly, 1).([]storageNode)
-
-	for i := range snapshot {
-		// V2 format
-		nodeInfo := snapshot[i].info
-		nodeKey := nodeInfo[2:35] // offset:2, len:33
-
-		if common.BytesEqual(key, nodeKey) {
-			return true
-		}
-	}
-
-	return false
+	return contract.Call(netmapContractAddr, "isInSnapshot", contract.ReadOnly, 1, key).(bool)
 }
 
 func updateEstimations(ctx storage.Context, epoch int, cid []byte, pub interop.PublicKey, isUpdate bool) {
@@ -865,6 +2221,261 @@ func updateEstimations(ctx storage.Context, epoch int, cid []byte, pub interop.P
 	common.SetSerialized(ctx, estKey, newEpochs)
 }
 
+// removeEstimations removes all size estimations of the container.
+func removeEstimations(ctx storage.Context, cid []byte) {
+	prefix := append([]byte(singleEstimatePrefix), cid...)
+	it := storage.Find(ctx, prefix, storage.None)
+	for iterator.Next(it) {
+		item := iterator.Value(it).(struct {
+			key   []byte
+			value []byte
+		})
+
+		h := item.key[len(prefix):]
+		epochs := std.Deserialize(item.value).([]int)
+		for _, epoch := range epochs {
+			key := append([]byte(estimateKeyPrefix), convert.ToBytes(epoch)...)
+			key = append(key, cid...)
+			key = append(key, h[:estimatePostfixSize]...)
+			storage.Delete(ctx, key)
+			storage.Delete(ctx, sizeSummaryKey(epoch, cid))
+		}
+
+		storage.Delete(ctx, item.key)
+	}
+}
+
+// cleanupOrphanedRecords removes extended ACLs and size estimations of
+// the containers which don't exist anymore. Previous versions of the contract
+// didn't remove them on container deletion.
+func cleanupOrphanedRecords(ctx storage.Context) {
+	it := storage.Find(ctx, eACLPrefix, storage.KeysOnly|storage.RemovePrefix)
+	for iterator.Next(it) {
+		cid := iterator.Value(it).([]byte)
+		if !containerExists(ctx, cid) {
+			storage.Delete(ctx, append(eACLPrefix, cid...))
+		}
+	}
+
+	it = storage.Find(ctx, []byte(singleEstimatePrefix), storage.KeysOnly|storage.RemovePrefix)
+	for iterator.Next(it) {
+		cid := iterator.Value(it).([]byte)[:containerIDSize]
+		if !containerExists(ctx, cid) {
+			removeEstimations(ctx, cid)
+		}
+	}
+
+	// Estimations which are not referenced by the lists of epochs.
+	it = storage.Find(ctx, []byte(estimateKeyPrefix), storage.KeysOnly)
+	for iterator.Next(it) {
+		k := iterator.Value(it).([]byte)
+		// V2 format
+		cid := k[len(k)-containerIDSize-estimatePostfixSize : len(k)-estimatePostfixSize]
+		if !containerExists(ctx, cid) {
+			storage.Delete(ctx, k)
+		}
+	}
+}
+
+func containerExists(ctx storage.Context, cid []byte) bool {
+	return storage.Get(ctx, append([]byte{containerKeyPrefix}, cid...)) != nil
+}
+
+func putContainerSize(ctx storage.Context, epoch int, cid []byte, usedSize int, pubKey interop.PublicKey, defaultQuota int) {
+	key := estimationKey(epoch, cid, pubKey)
+
+	s := estimation{
+		from: pubKey,
+		size: usedSize,
+	}
+
+	storage.Put(ctx, key, std.Serialize(s))
+	updateEstimations(ctx, epoch, cid, pubKey, false)
+
+	prev := getSizeSummary(ctx, epoch, cid)
+	summary := updateSizeSummary(ctx, epoch, cid)
+
+	limit := defaultQuota
+	data := storage.Get(ctx, append([]byte{quotaKeyPrefix}, cid...))
+	if data != nil {
+		limit = data.(int)
+	}
+	if limit > 0 && summary.Median > limit && prev.Median <= limit {
+		runtime.Notify("QuotaExceeded", cid, epoch, summary.Median, limit)
+	}
+}
+
+func getSizeSummary(ctx storage.Context, epoch int, cid []byte) SizeSummary {
+	data := storage.Get(ctx, sizeSummaryKey(epoch, cid))
+	if data != nil {
+		return std.Deserialize(data.([]byte)).(SizeSummary)
+	}
+
+	return SizeSummary{}
+}
+
+func updateSizeSummary(ctx storage.Context, epoch int, cid []byte) SizeSummary {
+	key := append([]byte(estimateKeyPrefix), convert.ToBytes(epoch)...)
+	key = append(key, cid...)
+
+	sizes := getContainerSizeEstimation(ctx, key, cid)
+	summary := summarizeEstimations(sizes.estimations)
+	common.SetSerialized(ctx, sizeSummaryKey(epoch, cid), summary)
+	return summary
+}
+
+func getQuota(ctx storage.Context, id []byte) int {
+	data := storage.Get(ctx, append([]byte{quotaKeyPrefix}, id...))
+	if data != nil {
+		return data.(int)
+	}
+
+	if len(id) != containerIDSize {
+		return 0
+	}
+	return getDefaultQuota(ctx)
+}
+
+func getDefaultQuota(ctx storage.Context) int {
+	netmapContractAddr := storage.Get(ctx, netmapContractKey).(interop.Hash160)
+	value := netmapConfig(netmapContractAddr, DefaultQuotaKey)
+	if value != nil {
+		return value.(int)
+	}
+	return 0
+}
+
+// summarizeEstimations returns summary of the estimations which deviate from
+// the median not more than MaxEstimationDeviation percents. Outliers can't be
+// detected if there are less than 3 estimations, so all of them are used then.
+func summarizeEstimations(estimations []estimation) SizeSummary {
+	var sizes []int
+	for i := range estimations {
+		sizes = append(sizes, estimations[i].size)
+	}
+	sortInts(sizes)
+
+	accepted := sizes
+	if len(sizes) >= 3 {
+		m := median(sizes)
+
+		accepted = []int{}
+		for _, size := range sizes {
+			d := size - m
+			if d < 0 {
+				d = -d
+			}
+			if d*100 <= m*MaxEstimationDeviation {
+				accepted = append(accepted, size)
+			}
+		}
+
+		if len(accepted) == 0 {
+			accepted = sizes
+		}
+	}
+
+	return SizeSummary{
+		Nodes:  len(accepted),
+		Median: median(accepted),
+		Min:    accepted[0],
+		Max:    accepted[len(accepted)-1],
+	}
+}
+
+// median returns the median of the sorted non-empty slice.
+func median(sizes []int) int {
+	n := len(sizes)
+	if n%2 == 1 {
+		return sizes[n/2]
+	}
+	return (sizes[n/2-1] + sizes[n/2]) / 2
+}
+
+// sortInts sorts the slice in ascending order. Insertion sort is used, because
+// the number of estimations for a single container is small.
+func sortInts(a []int) {
+	for i := 1; i < len(a); i++ {
+		for j := i; j > 0 && a[j-1] > a[j]; j-- {
+			tmp := a[j]
+			a[j] = a[j-1]
+			a[j-1] = tmp
+		}
+	}
+}
+
+// updateOwnerUsage sums the median sizes of containers per owner. If the total
+// size exceeds the owner quota, OwnerQuotaExceeded notification is produced.
+func updateOwnerUsage(ctx storage.Context, epoch int) {
+	prefix := append([]byte{ownerUsagePrefix}, epochKey(epoch)...)
+	it := storage.Find(ctx, prefix, storage.KeysOnly)
+	for iterator.Next(it) {
+		storage.Delete(ctx, iterator.Value(it).([]byte))
+	}
+
+	it = storage.Find(ctx, append([]byte{sizeSummaryPrefix}, epochKey(epoch)...), storage.RemovePrefix)
+	for iterator.Next(it) {
+		item := iterator.Value(it).(struct {
+			key   []byte
+			value []byte
+		})
+
+		owner := getOwnerByID(ctx, item.key)
+		if owner == nil {
+			continue
+		}
+
+		summary := std.Deserialize(item.value).(SizeSummary)
+		key := ownerUsageKey(epoch, owner)
+		usage := UsageInfo{Owner: owner, Size: 0}
+		data := storage.Get(ctx, key)
+		if data != nil {
+			usage = std.Deserialize(data.([]byte)).(UsageInfo)
+		}
+
+		usage.Size += summary.Median
+		common.SetSerialized(ctx, key, usage)
+	}
+
+	it = storage.Find(ctx, prefix, storage.ValuesOnly|storage.DeserializeValues)
+	for iterator.Next(it) {
+		usage := iterator.Value(it).(UsageInfo)
+		limit := getQuota(ctx, usage.Owner)
+		if limit > 0 && usage.Size > limit {
+			runtime.Notify("OwnerQuotaExceeded", usage.Owner, epoch, usage.Size, limit)
+		}
+	}
+}
+
+func ownerUsageKey(epoch int, owner []byte) []byte {
+	key := append([]byte{ownerUsagePrefix}, epochKey(epoch)...)
+	return append(key, owner...)
+}
+
+func sizeSummaryKey(epoch int, cid []byte) []byte {
+	key := append([]byte{sizeSummaryPrefix}, epochKey(epoch)...)
+	return append(key, cid...)
+}
+
+// cleanupSizeSummaries removes size summaries and owner usage of the epochs
+// older than TotalCleanupDelta.
+func cleanupSizeSummaries(ctx storage.Context, epoch int) {
+	cleanupEpochRecords(ctx, []byte{sizeSummaryPrefix}, epoch)
+	cleanupEpochRecords(ctx, []byte{ownerUsagePrefix}, epoch)
+}
+
+func cleanupEpochRecords(ctx storage.Context, prefix []byte, epoch int) {
+	it := storage.Find(ctx, prefix, storage.KeysOnly)
+	for iterator.Next(it) {
+		k := iterator.Value(it).([]byte)
+
+		var n interface{} = k[len(prefix) : len(prefix)+epochKeySize]
+		if epoch-n.(int) > TotalCleanupDelta {
+			storage.Delete(ctx, k)
+		}
+	}
+}
+
 func cleanupContainers(ctx storage.Context, epoch int) {
 	it := storage.Find(ctx, []byte(estimateKeyPrefix), storage.KeysOnly)
 	for iterator.Next(it) {
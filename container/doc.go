@@ -50,6 +50,96 @@ present.
 	  - name: token
 	    type: ByteArray
 
+transferOwnership notification. This notification is produced when a container
+owner wants to move a container to another owner. Alphabet nodes of the Inner
+Ring catch the notification and validate container ownership, signature and
+token if present.
+
+	transferOwnership:
+	  - name: containerID
+	    type: ByteArray
+	  - name: newOwner
+	    type: ByteArray
+	  - name: signature
+	    type: Signature
+	  - name: publicKey
+	    type: PublicKey
+	  - name: token
+	    type: ByteArray
+
+setQuota notification. This notification is produced when a container owner
+wants to set a size limit of the container or of all owned containers. Alphabet
+nodes of the Inner Ring catch the notification and validate ownership,
+signature and token if present.
+
+	setQuota:
+	  - name: id
+	    type: ByteArray
+	  - name: limit
+	    type: Integer
+	  - name: signature
+	    type: Signature
+	  - name: publicKey
+	    type: PublicKey
+	  - name: token
+	    type: ByteArray
+
+setAlias notification. This notification is produced when a container owner
+wants to set a nice name of the container. Alphabet nodes of the Inner Ring
+catch the notification and validate container ownership, signature and token
+if present.
+
+	setAlias:
+	  - name: containerID
+	    type: ByteArray
+	  - name: name
+	    type: String
+	  - name: zone
+	    type: String
+	  - name: signature
+	    type: Signature
+	  - name: token
+	    type: ByteArray
+
+removeAlias notification. This notification is produced when a container owner
+wants to remove a nice name of the container. Alphabet nodes of the Inner Ring
+catch the notification and validate container ownership, signature and token
+if present.
+
+	removeAlias:
+	  - name: containerID
+	    type: ByteArray
+	  - name: signature
+	    type: Signature
+	  - name: token
+	    type: ByteArray
+
+QuotaExceeded notification. This notification is produced when the size of
+the container exceeds its quota.
+
+	QuotaExceeded:
+	  - name: containerID
+	    type: ByteArray
+	  - name: epoch
+	    type: Integer
+	  - name: used
+	    type: Integer
+	  - name: limit
+	    type: Integer
+
+OwnerQuotaExceeded notification. This notification is produced when the total
+size of owner containers exceeds the owner quota.
+
+	OwnerQuotaExceeded:
+	  - name: owner
+	    type: ByteArray
+	  - name: epoch
+	    type: Integer
+	  - name: used
+	    type: Integer
+	  - name: limit
+	    type: Integer
+
 StartEstimation notification. This notification is produced when Storage nodes
 should exchange estimation values of container sizes among other Storage nodes.
 
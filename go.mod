@@ -3,6 +3,7 @@ module github.com/TrueCloudLab/frostfs-contract
 go 1.14
 
 require (
+	github.com/google/uuid v1.2.0
 	github.com/mr-tron/base58 v1.2.0
 	github.com/nspcc-dev/neo-go v0.99.4
 	github.com/nspcc-dev/neo-go/pkg/interop v0.0.0-20220927123257-24c107e3a262
@@ -24,6 +24,26 @@ states: (2) -- offline.
 	  - name: publicKey
 	    type: PublicKey
 
+UpdateStateWithDeadline notification. This notification is produced when a
+Storage node wants to go to maintenance until the specified epoch by invoking
+UpdateStateWithDeadline method.
+
+	UpdateStateWithDeadline
+	  - name: state
+	    type: Integer
+	  - name: publicKey
+	    type: PublicKey
+	  - name: deadline
+	    type: Integer
+
+PeerEvicted notification. This notification is produced when a candidate is
+removed from the candidate set on new epoch, because it hasn't sent a bootstrap
+request for MaxNodeTTL epochs.
+
+	PeerEvicted
+	  - name: publicKey
+	    type: PublicKey
+
 NewEpoch notification. This notification is produced when a new epoch is applied
 in the network by invoking NewEpoch method.
 
@@ -32,6 +32,46 @@ const (
 	NodeStateMaintenance
 )
 
+// ConfigType is an enumeration for types of the known configuration values.
+type ConfigType int
+
+// Various configuration value types.
+const (
+	_ ConfigType = iota
+
+	// ConfigTypeInt stands for integer values.
+	ConfigTypeInt
+
+	// ConfigTypeBool stands for boolean values.
+	ConfigTypeBool
+
+	// ConfigTypeBytes stands for arbitrary byte arrays.
+	ConfigTypeBytes
+
+	// ConfigTypeHash160 stands for script hashes.
+	ConfigTypeHash160
+)
+
+// configEntry describes the type of the known configuration value. Integer
+// values must be in [min, max] range.
+type configEntry struct {
+	typ ConfigType
+	min int
+	max int
+}
+
+// epochInfo groups data related to the start of the epoch.
+type epochInfo struct {
+	// Epoch number.
+	Epoch int
+
+	// Index of the block in which the epoch was applied.
+	Block int
+
+	// Timestamp of the block in which the epoch was applied (in milliseconds).
+	Time int
+}
+
 // Node groups data related to FrostFS storage nodes registered in the FrostFS
 // network. The information is stored in the current contract.
 type Node struct {
@@ -51,10 +91,39 @@ const (
 	// Must be less than 255.
 	DefaultSnapshotCount = 10
 	snapshotCountKey     = "snapshotCount"
-	snapshotKeyPrefix    = "snapshot_"
 	snapshotCurrentIDKey = "snapshotCurrent"
 	snapshotEpoch        = "snapshotEpoch"
 	snapshotBlockKey     = "snapshotBlock"
+	snapshotNodesPrefix  = "snapshotNodes_"
+
+	// EpochHistorySize contains the number of previous epochs which block
+	// and time are stored by this contract. Must be less than 255.
+	EpochHistorySize   = 100
+	epochHistoryPrefix = "epochHistory_"
+
+	// legacySnapshotKeyPrefix is a prefix of the snapshots stored as a single
+	// serialized array of nodes. Such snapshots are migrated to per-node
+	// records on contract update.
+	legacySnapshotKeyPrefix = "snapshot_"
+
+	// MaintenanceExpiryOnlineKey is a key in netmap config which contains
+	// a boolean flag. If set, nodes with the expired maintenance deadline are
+	// moved to the online state instead of being removed from the candidates.
+	MaintenanceExpiryOnlineKey = "MaintenanceExpiryOnline"
+
+	// MaxNodeTTLKey is a key in netmap config which contains the number of
+	// epochs a candidate stays in the candidate set without re-bootstrap.
+	// Zero or missing value disables the eviction.
+	MaxNodeTTLKey = "MaxNodeTTL"
+
+	// UnknownConfigAllowedKey is a key in netmap config which contains
+	// a boolean flag. If set, values of the keys missing in the configuration
+	// schema are accepted without validation.
+	UnknownConfigAllowedKey = "UnknownConfigAllowed"
+
+	// unbounded is used as a maximum of the integer configuration value
+	// which has no upper limit.
+	unbounded = -1
 
 	containerContractKey = "containerScriptHash"
 	balanceContractKey   = "balanceScriptHash"
@@ -63,8 +132,10 @@ const (
 )
 
 var (
-	configPrefix    = []byte("config")
-	candidatePrefix = []byte("candidate")
+	configPrefix      = []byte("config")
+	candidatePrefix   = []byte("candidate")
+	maintenancePrefix = []byte("maintenance")
+	bootstrapPrefix   = []byte("bootstrapEpoch")
 )
 
 // _deploy function sets up initial list of inner ring public keys.
@@ -94,6 +165,47 @@ func _deploy(data interface{}, isUpdate bool) {
 
 	if isUpdate {
 		common.CheckVersion(args.version)
+
+		// Values stored by the previous version haven't been validated.
+		it := storage.Find(ctx, configPrefix, storage.RemovePrefix)
+		for iterator.Next(it) {
+			kv := iterator.Value(it).(struct {
+				key []byte
+				val interface{}
+			})
+			checkConfig(ctx, kv.key, kv.val)
+		}
+
+		epoch := storage.Get(ctx, snapshotEpoch).(int)
+		it = storage.Find(ctx, candidatePrefix, storage.KeysOnly|storage.RemovePrefix)
+		for iterator.Next(it) {
+			key := append(bootstrapPrefix, iterator.Value(it).([]byte)...)
+			if storage.Get(ctx, key) == nil {
+				storage.Put(ctx, key, epoch)
+			}
+		}
+
+		count := getSnapshotCount(ctx)
+		for i := 0; i < count; i++ {
+			key := legacySnapshotKeyPrefix + string([]byte{byte(i)})
+			data := storage.Get(ctx, key)
+			if data == nil {
+				continue
+			}
+
+			putSnapshot(ctx, i, std.Deserialize(data.([]byte)).([]Node))
+			storage.Delete(ctx, key)
+		}
+
+		// Epoch history is missing if the contract is updated from the version
+		// which doesn't store it. Time of the current epoch is unknown then.
+		if storage.Get(ctx, epochInfoKey(epoch)) == nil {
+			putEpochInfo(ctx, epochInfo{
+				Epoch: epoch,
+				Block: storage.Get(ctx, snapshotBlockKey).(int),
+				Time:  0,
+			})
+		}
 		return
 	}
 
@@ -105,11 +217,6 @@ func _deploy(data interface{}, isUpdate bool) {
 	storage.Put(ctx, snapshotCountKey, DefaultSnapshotCount)
 	storage.Put(ctx, snapshotEpoch, 0)
 	storage.Put(ctx, snapshotBlockKey, 0)
-
-	prefix := []byte(snapshotKeyPrefix)
-	for i := 0; i < DefaultSnapshotCount; i++ {
-		common.SetSerialized(ctx, append(prefix, byte(i)), []Node{})
-	}
 	storage.Put(ctx, snapshotCurrentIDKey, 0)
 
 	storage.Put(ctx, balanceContractKey, args.addrBalance)
@@ -291,10 +398,11 @@ func AddPeer(nodeInfo []byte) {
 }
 
 // updates state of the network map candidate by its public key in the contract
-// storage, and throws UpdateStateSuccess notification after this.
+// storage, and throws UpdateStateSuccess notification after this. Non-zero
+// deadline is stored for the maintenance state only.
 //
 // State MUST be from the NodeState enum.
-func updateCandidateState(ctx storage.Context, publicKey interop.PublicKey, state NodeState) {
+func updateCandidateState(ctx storage.Context, publicKey interop.PublicKey, state NodeState, deadline int) {
 	switch state {
 	case NodeStateOffline:
 		removeFromNetmap(ctx, publicKey)
@@ -306,9 +414,24 @@ func updateCandidateState(ctx storage.Context, publicKey interop.PublicKey, stat
 		panic("unsupported state")
 	}
 
+	if deadline != 0 {
+		storage.Put(ctx, append(maintenancePrefix, publicKey...), deadline)
+	}
+
 	runtime.Notify("UpdateStateSuccess", publicKey, state)
 }
 
+// checkDeadline panics if the maintenance deadline can't be applied to the
+// given state.
+func checkDeadline(ctx storage.Context, state NodeState, deadline int) {
+	if state != NodeStateMaintenance {
+		panic("deadline is supported for maintenance state only")
+	}
+	if deadline <= storage.Get(ctx, snapshotEpoch).(int) {
+		panic("invalid maintenance deadline")
+	}
+}
+
 // UpdateState accepts new state to be assigned to network map candidate
 // identified by the given public key, identifies the signer and behaves
 // depending on different conditions listed below.
@@ -344,11 +467,30 @@ func updateCandidateState(ctx storage.Context, publicKey interop.PublicKey, stat
 // State MUST be from the NodeState enum. Public key MUST be
 // interop.PublicKeyCompressedLen bytes.
 func UpdateState(state NodeState, publicKey interop.PublicKey) {
+	ctx := storage.GetContext()
+	updateState(ctx, state, publicKey, 0)
+}
+
+// UpdateStateWithDeadline behaves like UpdateState, but moves the candidate
+// to the maintenance state until the given epoch. When the deadline epoch is
+// applied, the candidate is removed from the candidate set or moved to the
+// online state if MaintenanceExpiryOnlineKey config flag is set. In
+// notary-disabled contract setting, UpdateStateWithDeadline notification
+// is thrown instead of UpdateState one.
+//
+// State MUST be NodeStateMaintenance. Deadline MUST be greater than the
+// current epoch.
+func UpdateStateWithDeadline(state NodeState, publicKey interop.PublicKey, deadline int) {
+	ctx := storage.GetContext()
+	checkDeadline(ctx, state, deadline)
+	updateState(ctx, state, publicKey, deadline)
+}
+
+func updateState(ctx storage.Context, state NodeState, publicKey interop.PublicKey, deadline int) {
 	if len(publicKey) != interop.PublicKeyCompressedLen {
 		panic("incorrect public key")
 	}
 
-	ctx := storage.GetContext()
 	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
 
 	if notaryDisabled {
@@ -359,12 +501,20 @@ func UpdateState(state NodeState, publicKey interop.PublicKey) {
 		// just emit the notification for alphabet.
 		if len(nodeKey) == 0 {
 			common.CheckWitness(publicKey)
-			runtime.Notify("UpdateState", state, publicKey)
+			if deadline != 0 {
+				runtime.Notify("UpdateStateWithDeadline", state, publicKey, deadline)
+			} else {
+				runtime.Notify("UpdateState", state, publicKey)
+			}
 			return
 		}
 
 		threshold := len(alphabet)*2/3 + 1
-		id := common.InvokeID([]interface{}{state, publicKey}, []byte("update"))
+		args := []interface{}{state, publicKey}
+		if deadline != 0 {
+			args = append(args, deadline)
+		}
+		id := common.InvokeID(args, []byte("update"))
 
 		n := common.Vote(ctx, id, nodeKey)
 		if n < threshold {
@@ -377,7 +527,7 @@ func UpdateState(state NodeState, publicKey interop.PublicKey) {
 		common.CheckAlphabetWitness(common.AlphabetAddress())
 	}
 
-	updateCandidateState(ctx, publicKey, state)
+	updateCandidateState(ctx, publicKey, state, deadline)
 }
 
 // UpdateStateIR accepts Alphabet calls in the notary-enabled contract setting
@@ -395,7 +545,26 @@ func UpdateStateIR(state NodeState, publicKey interop.PublicKey) {
 
 	common.CheckAlphabetWitness(common.AlphabetAddress())
 
-	updateCandidateState(ctx, publicKey, state)
+	updateCandidateState(ctx, publicKey, state, 0)
+}
+
+// UpdateStateWithDeadlineIR accepts Alphabet calls in the notary-enabled
+// contract setting and behaves similar to UpdateStateWithDeadline, but does
+// not require candidate's signature presence.
+//
+// UpdateStateWithDeadlineIR MUST NOT be called in notary-disabled contract setting.
+// UpdateStateWithDeadlineIR MUST be called by the Alphabet member only.
+func UpdateStateWithDeadlineIR(state NodeState, publicKey interop.PublicKey, deadline int) {
+	ctx := storage.GetContext()
+	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
+	if notaryDisabled {
+		panic("UpdateStateWithDeadlineIR should only be called in notary-enabled environment")
+	}
+
+	common.CheckAlphabetWitness(common.AlphabetAddress())
+	checkDeadline(ctx, state, deadline)
+
+	updateCandidateState(ctx, publicKey, state, deadline)
 }
 
 // NewEpoch method changes the epoch number up to the provided epochNum argument. It can
@@ -444,6 +613,8 @@ func NewEpoch(epochNum int) {
 		panic("invalid epoch") // ignore invocations with invalid epoch
 	}
 
+	evictCandidates(ctx, epochNum)
+	expireMaintenance(ctx, epochNum)
 	dataOnlineState := filterNetmap(ctx)
 
 	runtime.Log("process new epoch")
@@ -451,13 +622,18 @@ func NewEpoch(epochNum int) {
 	// todo: check if provided epoch number is bigger than current
 	storage.Put(ctx, snapshotEpoch, epochNum)
 	storage.Put(ctx, snapshotBlockKey, ledger.CurrentIndex())
+	putEpochInfo(ctx, epochInfo{
+		Epoch: epochNum,
+		Block: ledger.CurrentIndex(),
+		Time:  runtime.GetTime(),
+	})
 
 	id := storage.Get(ctx, snapshotCurrentIDKey).(int)
 	id = (id + 1) % getSnapshotCount(ctx)
 	storage.Put(ctx, snapshotCurrentIDKey, id)
 
 	// put netmap into actual snapshot
-	common.SetSerialized(ctx, snapshotKeyPrefix+string([]byte{byte(id)}), dataOnlineState)
+	putSnapshot(ctx, id, dataOnlineState)
 
 	// make clean up routines in other contracts
 	cleanup(ctx, epochNum)
@@ -477,6 +653,48 @@ func LastEpochBlock() int {
 	return storage.Get(ctx, snapshotBlockKey).(int)
 }
 
+// EpochBlock method returns the block number when the given epoch was applied.
+// Only last EpochHistorySize epochs are stored in the contract, the method
+// panics if the epoch is missing.
+func EpochBlock(epoch int) int {
+	ctx := storage.GetReadOnlyContext()
+	return getEpochInfo(ctx, epoch).Block
+}
+
+// EpochTime method returns the timestamp (in milliseconds) of the block
+// when the given epoch was applied. Epoch has the same restrictions as in
+// EpochBlock method. Zero is returned for the epoch which was current when
+// the contract was updated from the version without epoch history.
+func EpochTime(epoch int) int {
+	ctx := storage.GetReadOnlyContext()
+	return getEpochInfo(ctx, epoch).Time
+}
+
+// EpochByBlock method returns the number of the epoch which was current at the
+// given block height. The method panics if the height is older than the stored
+// epoch history, see EpochBlock.
+func EpochByBlock(height int) int {
+	ctx := storage.GetReadOnlyContext()
+
+	found := false
+	var result epochInfo
+
+	it := storage.Find(ctx, []byte(epochHistoryPrefix), storage.ValuesOnly|storage.DeserializeValues)
+	for iterator.Next(it) {
+		info := iterator.Value(it).(epochInfo)
+		if info.Block <= height && (!found || result.Block < info.Block) {
+			found = true
+			result = info
+		}
+	}
+
+	if !found {
+		panic("block is out of epoch history")
+	}
+
+	return result.Epoch
+}
+
 // Netmap returns set of information about the storage nodes representing a network
 // map in the current epoch.
 //
@@ -486,7 +704,7 @@ func LastEpochBlock() int {
 func Netmap() []Node {
 	ctx := storage.GetReadOnlyContext()
 	id := storage.Get(ctx, snapshotCurrentIDKey).(int)
-	return getSnapshot(ctx, snapshotKeyPrefix+string([]byte{byte(id)}))
+	return getSnapshot(ctx, id)
 }
 
 // NetmapCandidates returns set of information about the storage nodes
@@ -510,17 +728,106 @@ func NetmapCandidates() []Node {
 // Current state of each node is represented in the State field. It MAY differ
 // with the state encoded into BLOB field, in this case binary encoded state
 // MUST NOT be processed.
+//
+// Nodes are ordered by their public keys, not by the order they were added
+// to the network map.
 func Snapshot(diff int) []Node {
 	ctx := storage.GetReadOnlyContext()
+	return getSnapshot(ctx, snapshotID(ctx, diff))
+}
+
+// SnapshotIterator returns an iterator over the storage nodes representing
+// a network map in (current-diff)-th epoch. Unlike Snapshot, it doesn't
+// load the whole network map at once, so it can be used for large networks.
+// Nodes are ordered by their public keys.
+//
+// Diff has the same restrictions as in Snapshot method.
+func SnapshotIterator(diff int) iterator.Iterator {
+	ctx := storage.GetReadOnlyContext()
+	prefix := snapshotPrefix(snapshotID(ctx, diff))
+	return storage.Find(ctx, prefix, storage.ValuesOnly|storage.DeserializeValues)
+}
+
+// IsInSnapshot returns true if the storage node with the specified public key
+// is present in the network map in (current-diff)-th epoch. It is cheaper than
+// looking for the node in the Snapshot result, because nodes of each snapshot
+// are stored by public keys.
+//
+// Diff has the same restrictions as in Snapshot method.
+func IsInSnapshot(diff int, publicKey interop.PublicKey) bool {
+	ctx := storage.GetReadOnlyContext()
+	key := append(snapshotPrefix(snapshotID(ctx, diff)), publicKey...)
+	return storage.Get(ctx, key) != nil
+}
+
+// NetmapDiff groups changes of the network map between two epochs.
+type NetmapDiff struct {
+	// Nodes that are present only in the later network map.
+	Added []Node
+	// Nodes that are present only in the earlier network map.
+	Removed []Node
+	// Nodes that are present in both network maps, but have different
+	// BLOB or State. Nodes are taken from the later network map.
+	Changed []Node
+}
+
+// SnapshotDiff returns changes of the network map between epochA and epochB.
+// Nodes are considered the same if they have the same public key.
+//
+// Both epochs have the same restrictions as in SnapshotByEpoch method.
+func SnapshotDiff(epochA, epochB int) NetmapDiff {
+	ctx := storage.GetReadOnlyContext()
+	currentEpoch := storage.Get(ctx, snapshotEpoch).(int)
+
+	prefixA := snapshotPrefix(snapshotID(ctx, currentEpoch-epochA))
+	prefixB := snapshotPrefix(snapshotID(ctx, currentEpoch-epochB))
+
+	result := NetmapDiff{
+		Added:   []Node{},
+		Removed: []Node{},
+		Changed: []Node{},
+	}
+
+	it := storage.Find(ctx, prefixA, storage.RemovePrefix)
+	for iterator.Next(it) {
+		kv := iterator.Value(it).(struct {
+			key []byte
+			val []byte
+		})
+
+		data := storage.Get(ctx, append(prefixB, kv.key...))
+		if data == nil {
+			result.Removed = append(result.Removed, std.Deserialize(kv.val).(Node))
+		} else if !common.BytesEqual(kv.val, data.([]byte)) {
+			result.Changed = append(result.Changed, std.Deserialize(data.([]byte)).(Node))
+		}
+	}
+
+	it = storage.Find(ctx, prefixB, storage.RemovePrefix)
+	for iterator.Next(it) {
+		kv := iterator.Value(it).(struct {
+			key []byte
+			val []byte
+		})
+
+		if storage.Get(ctx, append(prefixA, kv.key...)) == nil {
+			result.Added = append(result.Added, std.Deserialize(kv.val).(Node))
+		}
+	}
+
+	return result
+}
+
+// snapshotID returns the identifier of the snapshot representing a network
+// map in (current-diff)-th epoch.
+func snapshotID(ctx storage.Context, diff int) int {
 	count := getSnapshotCount(ctx)
 	if diff < 0 || count <= diff {
 		panic("incorrect diff")
 	}
 
 	id := storage.Get(ctx, snapshotCurrentIDKey).(int)
-	needID := (id - diff + count) % count
-	key := snapshotKeyPrefix + string([]byte{byte(needID)})
-	return getSnapshot(ctx, key)
+	return (id - diff + count) % count
 }
 
 func getSnapshotCount(ctx storage.Context) int {
@@ -599,16 +906,45 @@ func UpdateSnapshotCount(count int) {
 		delStart, delFinish = count, curr
 	}
 	for k := delStart; k < delFinish; k++ {
-		key := snapshotKeyPrefix + string([]byte{byte(k)})
-		storage.Delete(ctx, key)
+		removeSnapshot(ctx, k)
 	}
 }
 
 func moveSnapshot(ctx storage.Context, from, to int) {
-	keyFrom := snapshotKeyPrefix + string([]byte{byte(from)})
-	keyTo := snapshotKeyPrefix + string([]byte{byte(to)})
-	data := storage.Get(ctx, keyFrom)
-	storage.Put(ctx, keyTo, data)
+	removeSnapshot(ctx, to)
+
+	prefixTo := snapshotPrefix(to)
+	it := storage.Find(ctx, snapshotPrefix(from), storage.RemovePrefix)
+	for iterator.Next(it) {
+		kv := iterator.Value(it).(struct {
+			key []byte
+			val []byte
+		})
+		storage.Put(ctx, append(prefixTo, kv.key...), kv.val)
+	}
+}
+
+func snapshotPrefix(id int) []byte {
+	return append([]byte(snapshotNodesPrefix), byte(id))
+}
+
+// putSnapshot replaces nodes of the snapshot with the given ones. Each node
+// is stored in a separate record by its public key.
+func putSnapshot(ctx storage.Context, id int, nodes []Node) {
+	removeSnapshot(ctx, id)
+
+	prefix := snapshotPrefix(id)
+	for i := range nodes {
+		publicKey := nodes[i].BLOB[2:35] // V2 format: offset:2, len:33
+		storage.Put(ctx, append(prefix, publicKey...), std.Serialize(nodes[i]))
+	}
+}
+
+func removeSnapshot(ctx storage.Context, id int) {
+	it := storage.Find(ctx, snapshotPrefix(id), storage.KeysOnly)
+	for iterator.Next(it) {
+		storage.Delete(ctx, iterator.Value(it).([]byte))
+	}
 }
 
 // SnapshotByEpoch returns set of information about the storage nodes representing
@@ -630,8 +966,39 @@ func Config(key []byte) interface{} {
 	return getConfig(ctx, key)
 }
 
+// ConfigTyped returns configuration value of FrostFS configuration converted to
+// the type registered in the configuration schema: integer, boolean or byte
+// array (for both byte array and script hash values). If key does not exists,
+// returns nil. Panics if the key is missing in the schema.
+func ConfigTyped(key []byte) interface{} {
+	ctx := storage.GetReadOnlyContext()
+
+	entry := configSchema(string(key))
+	if entry.typ == 0 {
+		panic("unknown configuration key: " + string(key))
+	}
+
+	val := getConfig(ctx, key)
+	if val == nil {
+		return nil
+	}
+
+	switch entry.typ {
+	case ConfigTypeInt:
+		return val.(int)
+	case ConfigTypeBool:
+		return val.(bool)
+	default:
+		return val
+	}
+}
+
 // SetConfig key-value pair as a FrostFS runtime configuration value. It can be invoked
 // only by Alphabet nodes.
+//
+// Value MUST match the type and bounds of the key in the configuration schema.
+// Keys missing in the schema are rejected unless UnknownConfigAllowedKey
+// flag is set.
 func SetConfig(id, key, val []byte) {
 	ctx := storage.GetContext()
 	notaryDisabled := storage.Get(ctx, notaryDisabledKey).(bool)
@@ -706,6 +1073,8 @@ func Version() int {
 func addToNetmap(ctx storage.Context, publicKey []byte, node Node) {
 	storageKey := append(candidatePrefix, publicKey...)
 	storage.Put(ctx, storageKey, std.Serialize(node))
+	storage.Delete(ctx, append(maintenancePrefix, publicKey...))
+	storage.Put(ctx, append(bootstrapPrefix, publicKey...), storage.Get(ctx, snapshotEpoch).(int))
 
 	runtime.Notify("AddPeerSuccess", interop.PublicKey(publicKey))
 }
@@ -713,6 +1082,8 @@ func addToNetmap(ctx storage.Context, publicKey []byte, node Node) {
 func removeFromNetmap(ctx storage.Context, key interop.PublicKey) {
 	storageKey := append(candidatePrefix, key...)
 	storage.Delete(ctx, storageKey)
+	storage.Delete(ctx, append(maintenancePrefix, key...))
+	storage.Delete(ctx, append(bootstrapPrefix, key...))
 }
 
 func updateNetmapState(ctx storage.Context, key interop.PublicKey, state NodeState) {
@@ -724,6 +1095,62 @@ func updateNetmapState(ctx storage.Context, key interop.PublicKey, state NodeSta
 	node := std.Deserialize(raw).(Node)
 	node.State = state
 	storage.Put(ctx, storageKey, std.Serialize(node))
+	storage.Delete(ctx, append(maintenancePrefix, key...))
+}
+
+// evictCandidates removes candidates which haven't been bootstrapped for more
+// than MaxNodeTTLKey epochs before the given one and throws PeerEvicted
+// notification for each of them. Candidates in the maintenance state are
+// not evicted. Bootstrap epochs of missing candidates are removed.
+func evictCandidates(ctx storage.Context, epoch int) {
+	ttl := getConfig(ctx, []byte(MaxNodeTTLKey))
+	if ttl == nil || ttl.(int) <= 0 {
+		return
+	}
+
+	it := storage.Find(ctx, bootstrapPrefix, storage.RemovePrefix)
+	for iterator.Next(it) {
+		kv := iterator.Value(it).(struct {
+			key []byte
+			val interface{}
+		})
+		if epoch-kv.val.(int) <= ttl.(int) {
+			continue
+		}
+
+		raw := storage.Get(ctx, append(candidatePrefix, kv.key...))
+		if raw == nil {
+			storage.Delete(ctx, append(bootstrapPrefix, kv.key...))
+			continue
+		}
+		if std.Deserialize(raw.([]byte)).(Node).State == NodeStateMaintenance {
+			continue
+		}
+
+		removeFromNetmap(ctx, kv.key)
+		runtime.Notify("PeerEvicted", interop.PublicKey(kv.key))
+	}
+}
+
+// expireMaintenance moves candidates with the maintenance deadline not
+// later than the given epoch out of the maintenance state.
+func expireMaintenance(ctx storage.Context, epoch int) {
+	state := NodeStateOffline
+	expiryOnline := getConfig(ctx, []byte(MaintenanceExpiryOnlineKey))
+	if expiryOnline != nil && expiryOnline.(bool) {
+		state = NodeStateOnline
+	}
+
+	it := storage.Find(ctx, maintenancePrefix, storage.RemovePrefix)
+	for iterator.Next(it) {
+		kv := iterator.Value(it).(struct {
+			key []byte
+			val interface{}
+		})
+		if kv.val.(int) <= epoch {
+			updateCandidateState(ctx, kv.key, state, 0)
+		}
+	}
 }
 
 func filterNetmap(ctx storage.Context) []Node {
@@ -754,13 +1181,38 @@ func getNetmapNodes(ctx storage.Context) []Node {
 	return result
 }
 
-func getSnapshot(ctx storage.Context, key string) []Node {
-	data := storage.Get(ctx, key)
-	if data != nil {
-		return std.Deserialize(data.([]byte)).([]Node)
+func getSnapshot(ctx storage.Context, id int) []Node {
+	result := []Node{}
+
+	it := storage.Find(ctx, snapshotPrefix(id), storage.ValuesOnly|storage.DeserializeValues)
+	for iterator.Next(it) {
+		node := iterator.Value(it).(Node)
+		result = append(result, node)
+	}
+
+	return result
+}
+
+func epochInfoKey(epoch int) []byte {
+	return append([]byte(epochHistoryPrefix), byte(epoch%EpochHistorySize))
+}
+
+func putEpochInfo(ctx storage.Context, info epochInfo) {
+	common.SetSerialized(ctx, epochInfoKey(info.Epoch), info)
+}
+
+func getEpochInfo(ctx storage.Context, epoch int) epochInfo {
+	if epoch >= 0 {
+		data := storage.Get(ctx, epochInfoKey(epoch))
+		if data != nil {
+			info := std.Deserialize(data.([]byte)).(epochInfo)
+			if info.Epoch == epoch {
+				return info
+			}
+		}
 	}
 
-	return []Node{}
+	panic("epoch is out of history")
 }
 
 func getConfig(ctx storage.Context, key interface{}) interface{} {
@@ -772,11 +1224,64 @@ func getConfig(ctx storage.Context, key interface{}) interface{} {
 
 func setConfig(ctx storage.Context, key, val interface{}) {
 	postfix := key.([]byte)
+	checkConfig(ctx, postfix, val)
 	storageKey := append(configPrefix, postfix...)
 
 	storage.Put(ctx, storageKey, val)
 }
 
+// configSchema returns the type and bounds of the known configuration value.
+// Zero type is returned for unknown keys.
+func configSchema(key string) configEntry {
+	switch key {
+	case "MaxObjectSize", "EpochDuration", "EigenTrustIterations",
+		"ContainerFeeEscrowPeriod":
+		return configEntry{typ: ConfigTypeInt, min: 1, max: unbounded}
+	case "BasicIncomeRate", "AuditFee", "InnerRingCandidateFee", "WithdrawFee",
+		"ContainerFee", "ContainerAliasFee", "ContainerTombstoneRetention",
+		"ContainerDefaultQuota", MaxNodeTTLKey:
+		return configEntry{typ: ConfigTypeInt, min: 0, max: unbounded}
+	case "ContainerFeeAlphabetShare", "ContainerFeeBurnShare":
+		return configEntry{typ: ConfigTypeInt, min: 0, max: 100}
+	case "HomomorphicHashingDisabled", "MaintenanceModeAllowed",
+		MaintenanceExpiryOnlineKey, UnknownConfigAllowedKey:
+		return configEntry{typ: ConfigTypeBool}
+	case "EigenTrustAlpha", "ContainerIndexedAttributes":
+		return configEntry{typ: ConfigTypeBytes}
+	case "ContainerFeeTreasury":
+		return configEntry{typ: ConfigTypeHash160}
+	}
+
+	return configEntry{}
+}
+
+// checkConfig panics if the value doesn't match the configuration schema.
+func checkConfig(ctx storage.Context, key []byte, val interface{}) {
+	entry := configSchema(string(key))
+	switch entry.typ {
+	case ConfigTypeInt:
+		v := val.(int)
+		if v < entry.min || (entry.max != unbounded && entry.max < v) {
+			panic("configuration value is out of range: " + string(key))
+		}
+	case ConfigTypeBool:
+		v := val.(int)
+		if v != 0 && v != 1 {
+			panic("invalid boolean configuration value: " + string(key))
+		}
+	case ConfigTypeBytes:
+	case ConfigTypeHash160:
+		if len(val.([]byte)) != interop.Hash160Len {
+			panic("invalid script hash configuration value: " + string(key))
+		}
+	default:
+		allowed := getConfig(ctx, []byte(UnknownConfigAllowedKey))
+		if allowed == nil || !allowed.(bool) {
+			panic("unknown configuration key: " + string(key))
+		}
+	}
+}
+
 func cleanup(ctx storage.Context, epoch int) {
 	balanceContractAddr := storage.Get(ctx, balanceContractKey).(interop.Hash160)
 	contract.Call(balanceContractAddr, cleanupEpochMethod, contract.All, epoch)
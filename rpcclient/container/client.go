@@ -0,0 +1,1492 @@
+// Package container contains RPC wrappers for FrostFS Container contract.
+//
+// Wrappers follow the contract ABI described in container/config.yml: safe
+// methods are available via ContractReader, state-changing methods via
+// Contract, and notifications can be parsed from application logs with
+// *EventsFromApplicationLog functions.
+package container
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Container represents a container record stored in the contract.
+type Container struct {
+	// Value is a stable marshaled Container structure from API.
+	Value []byte
+	// Sig is a RFC6979 signature of the Value.
+	Sig []byte
+	// Pub is a public key of the container creator.
+	Pub *keys.PublicKey
+	// Token is a stable marshaled SessionToken structure from API, can be empty.
+	Token []byte
+}
+
+// ExtendedACL represents an extended ACL record stored in the contract.
+type ExtendedACL struct {
+	// Value is a stable marshaled EACLTable structure from API.
+	Value []byte
+	// Sig is a RFC6979 signature of the Value.
+	Sig []byte
+	// Pub is a public key of the extended ACL setter.
+	Pub *keys.PublicKey
+	// Token is a stable marshaled SessionToken structure from API, can be empty.
+	Token []byte
+}
+
+// Estimation represents a single container size estimation of a storage node.
+type Estimation struct {
+	From *keys.PublicKey
+	Size *big.Int
+}
+
+// ContainerSizes represents a set of container size estimations.
+type ContainerSizes struct {
+	CID         []byte
+	Estimations []*Estimation
+}
+
+// SizeSummary contains aggregated size estimations of the container for
+// a single epoch.
+type SizeSummary struct {
+	Nodes  *big.Int
+	Median *big.Int
+	Min    *big.Int
+	Max    *big.Int
+}
+
+// UsageInfo contains the total size of containers of the owner in some epoch.
+type UsageInfo struct {
+	Owner []byte
+	Size  *big.Int
+}
+
+// ContainerSize contains the size of the container estimated by a storage
+// node, see PutContainerSizes.
+type ContainerSize struct {
+	CID  []byte
+	Size *big.Int
+}
+
+// DelInfo contains information about the deleted container.
+type DelInfo struct {
+	Owner []byte
+	Epoch *big.Int
+}
+
+// EACLHistoryItem represents a single extended ACL change of the container.
+type EACLHistoryItem struct {
+	EACL   *ExtendedACL
+	Epoch  *big.Int
+	Height *big.Int
+}
+
+// PutSuccessEvent represents "PutSuccess" event emitted by the contract.
+type PutSuccessEvent struct {
+	ContainerID util.Uint256
+	PublicKey   *keys.PublicKey
+}
+
+// DeleteSuccessEvent represents "DeleteSuccess" event emitted by the contract.
+type DeleteSuccessEvent struct {
+	ContainerID []byte
+}
+
+// SetEACLSuccessEvent represents "SetEACLSuccess" event emitted by the contract.
+type SetEACLSuccessEvent struct {
+	ContainerID []byte
+	PublicKey   *keys.PublicKey
+}
+
+// TransferOwnershipSuccessEvent represents "TransferOwnershipSuccess" event
+// emitted by the contract.
+type TransferOwnershipSuccessEvent struct {
+	ContainerID   []byte
+	PreviousOwner []byte
+	NewOwner      []byte
+}
+
+// SetQuotaSuccessEvent represents "SetQuotaSuccess" event emitted by the
+// contract.
+type SetQuotaSuccessEvent struct {
+	ID    []byte
+	Limit *big.Int
+}
+
+// SetAliasSuccessEvent represents "SetAliasSuccess" event emitted by the
+// contract.
+type SetAliasSuccessEvent struct {
+	ContainerID []byte
+	Domain      string
+}
+
+// RemoveAliasSuccessEvent represents "RemoveAliasSuccess" event emitted by the
+// contract.
+type RemoveAliasSuccessEvent struct {
+	ContainerID []byte
+	Domain      string
+}
+
+// QuotaExceededEvent represents "QuotaExceeded" event emitted by the contract.
+type QuotaExceededEvent struct {
+	ContainerID []byte
+	Epoch       *big.Int
+	Used        *big.Int
+	Limit       *big.Int
+}
+
+// OwnerQuotaExceededEvent represents "OwnerQuotaExceeded" event emitted by the
+// contract.
+type OwnerQuotaExceededEvent struct {
+	Owner []byte
+	Epoch *big.Int
+	Used  *big.Int
+	Limit *big.Int
+}
+
+// Invoker is used by ContractReader to call various safe methods.
+type Invoker interface {
+	Call(contract util.Uint160, operation string, params ...interface{}) (*result.Invoke, error)
+	CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...interface{}) (*result.Invoke, error)
+	TerminateSession(sessionID uuid.UUID) error
+	TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error)
+}
+
+// Actor is used by Contract to call state-changing methods.
+type Actor interface {
+	Invoker
+
+	MakeCall(contract util.Uint160, method string, params ...interface{}) (*transaction.Transaction, error)
+	MakeRun(script []byte) (*transaction.Transaction, error)
+	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...interface{}) (*transaction.Transaction, error)
+	MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error)
+	SendCall(contract util.Uint160, method string, params ...interface{}) (util.Uint256, uint32, error)
+	SendRun(script []byte) (util.Uint256, uint32, error)
+}
+
+// ContractReader implements safe contract methods.
+type ContractReader struct {
+	invoker Invoker
+	hash    util.Uint160
+}
+
+// Contract implements all contract methods.
+type Contract struct {
+	ContractReader
+	actor Actor
+	hash  util.Uint160
+}
+
+// NewReader creates an instance of ContractReader using the given contract
+// hash and Invoker.
+func NewReader(invoker Invoker, hash util.Uint160) *ContractReader {
+	return &ContractReader{invoker, hash}
+}
+
+// New creates an instance of Contract using the given contract hash and Actor.
+func New(actor Actor, hash util.Uint160) *Contract {
+	return &Contract{ContractReader{actor, hash}, actor, hash}
+}
+
+// Count invokes `count` method of contract.
+func (c *ContractReader) Count() (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "count"))
+}
+
+// CountByOwner invokes `countByOwner` method of contract.
+func (c *ContractReader) CountByOwner(owner []byte) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "countByOwner", owner))
+}
+
+// ContainersOf invokes `containersOf` method of contract.
+func (c *ContractReader) ContainersOf(owner []byte) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "containersOf", owner))
+}
+
+// ContainersOfExpanded is similar to ContainersOf (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) ContainersOfExpanded(owner []byte, _numOfIteratorItems int) ([][]byte, error) {
+	return unwrap.ArrayOfBytes(c.invoker.CallAndExpandIterator(c.hash, "containersOf", _numOfIteratorItems, owner))
+}
+
+// ContainersOfPaged invokes `containersOfPaged` method of contract.
+func (c *ContractReader) ContainersOfPaged(owner []byte, cursor []byte, limit *big.Int) ([][]byte, error) {
+	return itemToArrayOfBytes(unwrap.Item(c.invoker.Call(c.hash, "containersOfPaged", owner, cursor, limit)))
+}
+
+// ContainersByAttribute invokes `containersByAttribute` method of contract.
+func (c *ContractReader) ContainersByAttribute(key string, value string) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "containersByAttribute", key, value))
+}
+
+// ContainersByAttributeExpanded is similar to ContainersByAttribute (uses the
+// same contract method), but can be useful if the server used doesn't support
+// sessions and doesn't expand iterators. It creates a script that will get the
+// specified number of result items from the iterator right in the VM and
+// return them to you. It's only limited by VM stack and GAS available for RPC
+// invocations.
+func (c *ContractReader) ContainersByAttributeExpanded(key string, value string, _numOfIteratorItems int) ([][]byte, error) {
+	return unwrap.ArrayOfBytes(c.invoker.CallAndExpandIterator(c.hash, "containersByAttribute", _numOfIteratorItems, key, value))
+}
+
+// Get invokes `get` method of contract.
+func (c *ContractReader) Get(containerID []byte) (*Container, error) {
+	return itemToContainer(unwrap.Item(c.invoker.Call(c.hash, "get", containerID)))
+}
+
+// DeletionInfo invokes `deletionInfo` method of contract.
+func (c *ContractReader) DeletionInfo(containerID []byte) (*DelInfo, error) {
+	return itemToDelInfo(unwrap.Item(c.invoker.Call(c.hash, "deletionInfo", containerID)))
+}
+
+// DeletedContainers invokes `deletedContainers` method of contract.
+func (c *ContractReader) DeletedContainers(epoch *big.Int) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "deletedContainers", epoch))
+}
+
+// DeletedContainersExpanded is similar to DeletedContainers (uses the same
+// contract method), but can be useful if the server used doesn't support
+// sessions and doesn't expand iterators. It creates a script that will get
+// the specified number of result items from the iterator right in the VM and
+// return them to you. It's only limited by VM stack and GAS available for RPC
+// invocations.
+func (c *ContractReader) DeletedContainersExpanded(epoch *big.Int, _numOfIteratorItems int) ([][]byte, error) {
+	return unwrap.ArrayOfBytes(c.invoker.CallAndExpandIterator(c.hash, "deletedContainers", _numOfIteratorItems, epoch))
+}
+
+// Owner invokes `owner` method of contract.
+func (c *ContractReader) Owner(containerID []byte) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(c.hash, "owner", containerID))
+}
+
+// Alias invokes `alias` method of contract.
+func (c *ContractReader) Alias(containerID []byte) (string, error) {
+	return unwrap.UTF8String(c.invoker.Call(c.hash, "alias", containerID))
+}
+
+// ContainerByName invokes `containerByName` method of contract.
+func (c *ContractReader) ContainerByName(domain string) ([]byte, error) {
+	return unwrap.Bytes(c.invoker.Call(c.hash, "containerByName", domain))
+}
+
+// List invokes `list` method of contract.
+func (c *ContractReader) List(owner []byte) ([][]byte, error) {
+	return itemToArrayOfBytes(unwrap.Item(c.invoker.Call(c.hash, "list", owner)))
+}
+
+// EACL invokes `eACL` method of contract.
+func (c *ContractReader) EACL(containerID []byte) (*ExtendedACL, error) {
+	return itemToExtendedACL(unwrap.Item(c.invoker.Call(c.hash, "eACL", containerID)))
+}
+
+// EACLHistory invokes `eACLHistory` method of contract.
+func (c *ContractReader) EACLHistory(containerID []byte) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "eACLHistory", containerID))
+}
+
+// EACLHistoryExpanded is similar to EACLHistory (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) EACLHistoryExpanded(containerID []byte, _numOfIteratorItems int) ([]*EACLHistoryItem, error) {
+	items, err := unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "eACLHistory", _numOfIteratorItems, containerID))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*EACLHistoryItem, len(items))
+	for i := range items {
+		res[i] = new(EACLHistoryItem)
+		if err := res[i].FromStackItem(items[i]); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// EACLAt invokes `eACLAt` method of contract.
+func (c *ContractReader) EACLAt(containerID []byte, epoch *big.Int) (*ExtendedACL, error) {
+	return itemToExtendedACL(unwrap.Item(c.invoker.Call(c.hash, "eACLAt", containerID, epoch)))
+}
+
+// GetContainerSize invokes `getContainerSize` method of contract.
+func (c *ContractReader) GetContainerSize(id []byte) (*ContainerSizes, error) {
+	return itemToContainerSizes(unwrap.Item(c.invoker.Call(c.hash, "getContainerSize", id)))
+}
+
+// ContainerSizeSummary invokes `containerSizeSummary` method of contract.
+func (c *ContractReader) ContainerSizeSummary(epoch *big.Int, cid []byte) (*SizeSummary, error) {
+	return itemToSizeSummary(unwrap.Item(c.invoker.Call(c.hash, "containerSizeSummary", epoch, cid)))
+}
+
+// ListContainerSizes invokes `listContainerSizes` method of contract.
+func (c *ContractReader) ListContainerSizes(epoch *big.Int) ([][]byte, error) {
+	return itemToArrayOfBytes(unwrap.Item(c.invoker.Call(c.hash, "listContainerSizes", epoch)))
+}
+
+// IterateContainerSizes invokes `iterateContainerSizes` method of contract.
+func (c *ContractReader) IterateContainerSizes(epoch *big.Int) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "iterateContainerSizes", epoch))
+}
+
+// OwnerUsage invokes `ownerUsage` method of contract.
+func (c *ContractReader) OwnerUsage(owner []byte, epoch *big.Int) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "ownerUsage", owner, epoch))
+}
+
+// ListOwnerUsage invokes `listOwnerUsage` method of contract.
+func (c *ContractReader) ListOwnerUsage(epoch *big.Int) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "listOwnerUsage", epoch))
+}
+
+// ListOwnerUsageExpanded is similar to ListOwnerUsage (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) ListOwnerUsageExpanded(epoch *big.Int, _numOfIteratorItems int) ([]*UsageInfo, error) {
+	items, err := unwrap.Array(c.invoker.CallAndExpandIterator(c.hash, "listOwnerUsage", _numOfIteratorItems, epoch))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*UsageInfo, len(items))
+	for i := range items {
+		res[i] = new(UsageInfo)
+		if err := res[i].FromStackItem(items[i]); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// Quota invokes `quota` method of contract.
+func (c *ContractReader) Quota(id []byte) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "quota", id))
+}
+
+// Version invokes `version` method of contract.
+func (c *ContractReader) Version() (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "version"))
+}
+
+// Put creates a transaction invoking `put` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) Put(container []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "put", container, signature, publicKey.Bytes(), token)
+}
+
+// PutTransaction creates a transaction invoking `put` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutTransaction(container []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "put", container, signature, publicKey.Bytes(), token)
+}
+
+// PutUnsigned creates a transaction invoking `put` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutUnsigned(container []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "put", nil, container, signature, publicKey.Bytes(), token)
+}
+
+// PutNamed creates a transaction invoking `putNamed` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutNamed(container []byte, signature []byte, publicKey *keys.PublicKey, token []byte, name string, zone string) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putNamed", container, signature, publicKey.Bytes(), token, name, zone)
+}
+
+// PutNamedTransaction creates a transaction invoking `putNamed` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutNamedTransaction(container []byte, signature []byte, publicKey *keys.PublicKey, token []byte, name string, zone string) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putNamed", container, signature, publicKey.Bytes(), token, name, zone)
+}
+
+// PutNamedUnsigned creates a transaction invoking `putNamed` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutNamedUnsigned(container []byte, signature []byte, publicKey *keys.PublicKey, token []byte, name string, zone string) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putNamed", nil, container, signature, publicKey.Bytes(), token, name, zone)
+}
+
+// Delete creates a transaction invoking `delete` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) Delete(containerID []byte, signature []byte, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "delete", containerID, signature, token)
+}
+
+// DeleteTransaction creates a transaction invoking `delete` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) DeleteTransaction(containerID []byte, signature []byte, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "delete", containerID, signature, token)
+}
+
+// DeleteUnsigned creates a transaction invoking `delete` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) DeleteUnsigned(containerID []byte, signature []byte, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "delete", nil, containerID, signature, token)
+}
+
+// SetEACL creates a transaction invoking `setEACL` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) SetEACL(eACL []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "setEACL", eACL, signature, publicKey.Bytes(), token)
+}
+
+// SetEACLTransaction creates a transaction invoking `setEACL` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) SetEACLTransaction(eACL []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "setEACL", eACL, signature, publicKey.Bytes(), token)
+}
+
+// SetEACLUnsigned creates a transaction invoking `setEACL` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) SetEACLUnsigned(eACL []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "setEACL", nil, eACL, signature, publicKey.Bytes(), token)
+}
+
+// TransferOwnership creates a transaction invoking `transferOwnership` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) TransferOwnership(containerID []byte, newOwner []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "transferOwnership", containerID, newOwner, signature, publicKey.Bytes(), token)
+}
+
+// TransferOwnershipTransaction creates a transaction invoking `transferOwnership` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) TransferOwnershipTransaction(containerID []byte, newOwner []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "transferOwnership", containerID, newOwner, signature, publicKey.Bytes(), token)
+}
+
+// TransferOwnershipUnsigned creates a transaction invoking `transferOwnership` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) TransferOwnershipUnsigned(containerID []byte, newOwner []byte, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "transferOwnership", nil, containerID, newOwner, signature, publicKey.Bytes(), token)
+}
+
+// SetAlias creates a transaction invoking `setAlias` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) SetAlias(containerID []byte, name string, zone string, signature []byte, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "setAlias", containerID, name, zone, signature, token)
+}
+
+// SetAliasTransaction creates a transaction invoking `setAlias` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) SetAliasTransaction(containerID []byte, name string, zone string, signature []byte, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "setAlias", containerID, name, zone, signature, token)
+}
+
+// SetAliasUnsigned creates a transaction invoking `setAlias` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) SetAliasUnsigned(containerID []byte, name string, zone string, signature []byte, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "setAlias", nil, containerID, name, zone, signature, token)
+}
+
+// RemoveAlias creates a transaction invoking `removeAlias` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) RemoveAlias(containerID []byte, signature []byte, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "removeAlias", containerID, signature, token)
+}
+
+// RemoveAliasTransaction creates a transaction invoking `removeAlias` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) RemoveAliasTransaction(containerID []byte, signature []byte, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "removeAlias", containerID, signature, token)
+}
+
+// RemoveAliasUnsigned creates a transaction invoking `removeAlias` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) RemoveAliasUnsigned(containerID []byte, signature []byte, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "removeAlias", nil, containerID, signature, token)
+}
+
+// RejectPut creates a transaction invoking `rejectPut` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) RejectPut(containerID []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "rejectPut", containerID)
+}
+
+// RejectPutTransaction creates a transaction invoking `rejectPut` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) RejectPutTransaction(containerID []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "rejectPut", containerID)
+}
+
+// RejectPutUnsigned creates a transaction invoking `rejectPut` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) RejectPutUnsigned(containerID []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "rejectPut", nil, containerID)
+}
+
+// RejectAlias creates a transaction invoking `rejectAlias` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) RejectAlias(containerID []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "rejectAlias", containerID)
+}
+
+// RejectAliasTransaction creates a transaction invoking `rejectAlias` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) RejectAliasTransaction(containerID []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "rejectAlias", containerID)
+}
+
+// RejectAliasUnsigned creates a transaction invoking `rejectAlias` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) RejectAliasUnsigned(containerID []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "rejectAlias", nil, containerID)
+}
+
+// SetQuota creates a transaction invoking `setQuota` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) SetQuota(id []byte, limit *big.Int, signature []byte, publicKey *keys.PublicKey, token []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "setQuota", id, limit, signature, publicKey.Bytes(), token)
+}
+
+// SetQuotaTransaction creates a transaction invoking `setQuota` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) SetQuotaTransaction(id []byte, limit *big.Int, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "setQuota", id, limit, signature, publicKey.Bytes(), token)
+}
+
+// SetQuotaUnsigned creates a transaction invoking `setQuota` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) SetQuotaUnsigned(id []byte, limit *big.Int, signature []byte, publicKey *keys.PublicKey, token []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "setQuota", nil, id, limit, signature, publicKey.Bytes(), token)
+}
+
+// PutContainerSize creates a transaction invoking `putContainerSize` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutContainerSize(epoch *big.Int, cid []byte, usedSize *big.Int, pubKey *keys.PublicKey) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putContainerSize", epoch, cid, usedSize, pubKey.Bytes())
+}
+
+// PutContainerSizeTransaction creates a transaction invoking `putContainerSize` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutContainerSizeTransaction(epoch *big.Int, cid []byte, usedSize *big.Int, pubKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putContainerSize", epoch, cid, usedSize, pubKey.Bytes())
+}
+
+// PutContainerSizeUnsigned creates a transaction invoking `putContainerSize` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutContainerSizeUnsigned(epoch *big.Int, cid []byte, usedSize *big.Int, pubKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putContainerSize", nil, epoch, cid, usedSize, pubKey.Bytes())
+}
+
+// PutContainerSizes creates a transaction invoking `putContainerSizes` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) PutContainerSizes(epoch *big.Int, sizes []*ContainerSize, pubKey *keys.PublicKey) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "putContainerSizes", epoch, containerSizesToParam(sizes), pubKey.Bytes())
+}
+
+// PutContainerSizesTransaction creates a transaction invoking `putContainerSizes` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) PutContainerSizesTransaction(epoch *big.Int, sizes []*ContainerSize, pubKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "putContainerSizes", epoch, containerSizesToParam(sizes), pubKey.Bytes())
+}
+
+// PutContainerSizesUnsigned creates a transaction invoking `putContainerSizes` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) PutContainerSizesUnsigned(epoch *big.Int, sizes []*ContainerSize, pubKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "putContainerSizes", nil, epoch, containerSizesToParam(sizes), pubKey.Bytes())
+}
+
+// containerSizesToParam converts sizes to the contract parameter.
+func containerSizesToParam(sizes []*ContainerSize) []interface{} {
+	res := make([]interface{}, len(sizes))
+	for i := range sizes {
+		res[i] = []interface{}{sizes[i].CID, sizes[i].Size}
+	}
+	return res
+}
+
+// StartContainerEstimation creates a transaction invoking `startContainerEstimation` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) StartContainerEstimation(epoch *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "startContainerEstimation", epoch)
+}
+
+// StartContainerEstimationTransaction creates a transaction invoking `startContainerEstimation` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) StartContainerEstimationTransaction(epoch *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "startContainerEstimation", epoch)
+}
+
+// StartContainerEstimationUnsigned creates a transaction invoking `startContainerEstimation` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) StartContainerEstimationUnsigned(epoch *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "startContainerEstimation", nil, epoch)
+}
+
+// StopContainerEstimation creates a transaction invoking `stopContainerEstimation` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) StopContainerEstimation(epoch *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "stopContainerEstimation", epoch)
+}
+
+// StopContainerEstimationTransaction creates a transaction invoking `stopContainerEstimation` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) StopContainerEstimationTransaction(epoch *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "stopContainerEstimation", epoch)
+}
+
+// StopContainerEstimationUnsigned creates a transaction invoking `stopContainerEstimation` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) StopContainerEstimationUnsigned(epoch *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "stopContainerEstimation", nil, epoch)
+}
+
+// itemToContainer converts stack item into *Container.
+func itemToContainer(item stackitem.Item, err error) (*Container, error) {
+	if err != nil {
+		return nil, err
+	}
+	var res = new(Container)
+	err = res.FromStackItem(item)
+	return res, err
+}
+
+// FromStackItem retrieves fields of Container from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *Container) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.Value, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Value: %w", err)
+	}
+	res.Sig, err = arr[1].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Sig: %w", err)
+	}
+	res.Pub, err = itemToPublicKey(arr[2])
+	if err != nil {
+		return fmt.Errorf("field Pub: %w", err)
+	}
+	res.Token, err = arr[3].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Token: %w", err)
+	}
+	return nil
+}
+
+// itemToExtendedACL converts stack item into *ExtendedACL.
+func itemToExtendedACL(item stackitem.Item, err error) (*ExtendedACL, error) {
+	if err != nil {
+		return nil, err
+	}
+	var res = new(ExtendedACL)
+	err = res.FromStackItem(item)
+	return res, err
+}
+
+// FromStackItem retrieves fields of ExtendedACL from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *ExtendedACL) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.Value, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Value: %w", err)
+	}
+	res.Sig, err = arr[1].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Sig: %w", err)
+	}
+	res.Pub, err = itemToPublicKey(arr[2])
+	if err != nil {
+		return fmt.Errorf("field Pub: %w", err)
+	}
+	res.Token, err = arr[3].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Token: %w", err)
+	}
+	return nil
+}
+
+// FromStackItem retrieves fields of EACLHistoryItem from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *EACLHistoryItem) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.EACL, err = itemToExtendedACL(arr[0], nil)
+	if err != nil {
+		return fmt.Errorf("field EACL: %w", err)
+	}
+	res.Epoch, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Epoch: %w", err)
+	}
+	res.Height, err = arr[2].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Height: %w", err)
+	}
+	return nil
+}
+
+// itemToSizeSummary converts stack item into *SizeSummary.
+func itemToSizeSummary(item stackitem.Item, err error) (*SizeSummary, error) {
+	if err != nil {
+		return nil, err
+	}
+	var res = new(SizeSummary)
+	err = res.FromStackItem(item)
+	return res, err
+}
+
+// FromStackItem retrieves fields of SizeSummary from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *SizeSummary) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.Nodes, err = arr[0].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Nodes: %w", err)
+	}
+	res.Median, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Median: %w", err)
+	}
+	res.Min, err = arr[2].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Min: %w", err)
+	}
+	res.Max, err = arr[3].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Max: %w", err)
+	}
+	return nil
+}
+
+// FromStackItem retrieves fields of UsageInfo from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *UsageInfo) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.Owner, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Owner: %w", err)
+	}
+	res.Size, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Size: %w", err)
+	}
+	return nil
+}
+
+// itemToDelInfo converts stack item into *DelInfo.
+func itemToDelInfo(item stackitem.Item, err error) (*DelInfo, error) {
+	if err != nil {
+		return nil, err
+	}
+	var res = new(DelInfo)
+	err = res.FromStackItem(item)
+	return res, err
+}
+
+// FromStackItem retrieves fields of DelInfo from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *DelInfo) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.Owner, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Owner: %w", err)
+	}
+	res.Epoch, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Epoch: %w", err)
+	}
+	return nil
+}
+
+// itemToContainerSizes converts stack item into *ContainerSizes.
+func itemToContainerSizes(item stackitem.Item, err error) (*ContainerSizes, error) {
+	if err != nil {
+		return nil, err
+	}
+	var res = new(ContainerSizes)
+	err = res.FromStackItem(item)
+	return res, err
+}
+
+// FromStackItem retrieves fields of ContainerSizes from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *ContainerSizes) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.CID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field CID: %w", err)
+	}
+
+	// Contract returns Null if there are no estimations.
+	if _, ok := arr[1].(stackitem.Null); ok {
+		return nil
+	}
+	ests, ok := arr[1].Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("field Estimations: not an array")
+	}
+	res.Estimations = make([]*Estimation, len(ests))
+	for i := range ests {
+		res.Estimations[i] = new(Estimation)
+		if err := res.Estimations[i].FromStackItem(ests[i]); err != nil {
+			return fmt.Errorf("field Estimations, item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// FromStackItem retrieves fields of Estimation from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *Estimation) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.From, err = itemToPublicKey(arr[0])
+	if err != nil {
+		return fmt.Errorf("field From: %w", err)
+	}
+	res.Size, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Size: %w", err)
+	}
+	return nil
+}
+
+// PutSuccessEventsFromApplicationLog retrieves a set of all emitted events
+// with "PutSuccess" name from the provided ApplicationLog.
+func PutSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*PutSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*PutSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "PutSuccess" {
+				continue
+			}
+			event := new(PutSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize PutSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to PutSuccessEvent and
+// returns an error if so.
+func (e *PutSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	b, err := arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	e.ContainerID, err = util.Uint256DecodeBytesBE(b)
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	e.PublicKey, err = itemToPublicKey(arr[1])
+	if err != nil {
+		return fmt.Errorf("field PublicKey: %w", err)
+	}
+	return nil
+}
+
+// DeleteSuccessEventsFromApplicationLog retrieves a set of all emitted events
+// with "DeleteSuccess" name from the provided ApplicationLog.
+func DeleteSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*DeleteSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*DeleteSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "DeleteSuccess" {
+				continue
+			}
+			event := new(DeleteSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize DeleteSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to DeleteSuccessEvent and
+// returns an error if so.
+func (e *DeleteSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 1 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ContainerID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	return nil
+}
+
+// SetEACLSuccessEventsFromApplicationLog retrieves a set of all emitted events
+// with "SetEACLSuccess" name from the provided ApplicationLog.
+func SetEACLSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*SetEACLSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*SetEACLSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "SetEACLSuccess" {
+				continue
+			}
+			event := new(SetEACLSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize SetEACLSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to SetEACLSuccessEvent and
+// returns an error if so.
+func (e *SetEACLSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ContainerID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	e.PublicKey, err = itemToPublicKey(arr[1])
+	if err != nil {
+		return fmt.Errorf("field PublicKey: %w", err)
+	}
+	return nil
+}
+
+// TransferOwnershipSuccessEventsFromApplicationLog retrieves a set of all
+// emitted events with "TransferOwnershipSuccess" name from the provided
+// ApplicationLog.
+func TransferOwnershipSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*TransferOwnershipSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*TransferOwnershipSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "TransferOwnershipSuccess" {
+				continue
+			}
+			event := new(TransferOwnershipSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize TransferOwnershipSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to
+// TransferOwnershipSuccessEvent and returns an error if so.
+func (e *TransferOwnershipSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ContainerID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	e.PreviousOwner, err = arr[1].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field PreviousOwner: %w", err)
+	}
+	e.NewOwner, err = arr[2].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field NewOwner: %w", err)
+	}
+	return nil
+}
+
+// SetQuotaSuccessEventsFromApplicationLog retrieves a set of all emitted
+// events with "SetQuotaSuccess" name from the provided ApplicationLog.
+func SetQuotaSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*SetQuotaSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*SetQuotaSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "SetQuotaSuccess" {
+				continue
+			}
+			event := new(SetQuotaSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize SetQuotaSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to SetQuotaSuccessEvent and
+// returns an error if so.
+func (e *SetQuotaSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ID: %w", err)
+	}
+	e.Limit, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Limit: %w", err)
+	}
+	return nil
+}
+
+// SetAliasSuccessEventsFromApplicationLog retrieves a set of all emitted
+// events with "SetAliasSuccess" name from the provided ApplicationLog.
+func SetAliasSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*SetAliasSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*SetAliasSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "SetAliasSuccess" {
+				continue
+			}
+			event := new(SetAliasSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize SetAliasSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to SetAliasSuccessEvent and
+// returns an error if so.
+func (e *SetAliasSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ContainerID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	domain, err := arr[1].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Domain: %w", err)
+	}
+	e.Domain = string(domain)
+	return nil
+}
+
+// RemoveAliasSuccessEventsFromApplicationLog retrieves a set of all emitted
+// events with "RemoveAliasSuccess" name from the provided ApplicationLog.
+func RemoveAliasSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*RemoveAliasSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*RemoveAliasSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "RemoveAliasSuccess" {
+				continue
+			}
+			event := new(RemoveAliasSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize RemoveAliasSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to RemoveAliasSuccessEvent and
+// returns an error if so.
+func (e *RemoveAliasSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ContainerID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	domain, err := arr[1].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Domain: %w", err)
+	}
+	e.Domain = string(domain)
+	return nil
+}
+
+// QuotaExceededEventsFromApplicationLog retrieves a set of all emitted events
+// with "QuotaExceeded" name from the provided ApplicationLog.
+func QuotaExceededEventsFromApplicationLog(log *result.ApplicationLog) ([]*QuotaExceededEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*QuotaExceededEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "QuotaExceeded" {
+				continue
+			}
+			event := new(QuotaExceededEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize QuotaExceededEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to QuotaExceededEvent and
+// returns an error if so.
+func (e *QuotaExceededEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.ContainerID, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field ContainerID: %w", err)
+	}
+	e.Epoch, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Epoch: %w", err)
+	}
+	e.Used, err = arr[2].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Used: %w", err)
+	}
+	e.Limit, err = arr[3].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Limit: %w", err)
+	}
+	return nil
+}
+
+// OwnerQuotaExceededEventsFromApplicationLog retrieves a set of all emitted events
+// with "OwnerQuotaExceeded" name from the provided ApplicationLog.
+func OwnerQuotaExceededEventsFromApplicationLog(log *result.ApplicationLog) ([]*OwnerQuotaExceededEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*OwnerQuotaExceededEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "OwnerQuotaExceeded" {
+				continue
+			}
+			event := new(OwnerQuotaExceededEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize OwnerQuotaExceededEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to OwnerQuotaExceededEvent and
+// returns an error if so.
+func (e *OwnerQuotaExceededEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.Owner, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field Owner: %w", err)
+	}
+	e.Epoch, err = arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Epoch: %w", err)
+	}
+	e.Used, err = arr[2].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Used: %w", err)
+	}
+	e.Limit, err = arr[3].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Limit: %w", err)
+	}
+	return nil
+}
+
+// itemToPublicKey converts stack item into *keys.PublicKey. Contract
+// returns empty byte array for missing keys, it is converted to nil.
+func itemToPublicKey(item stackitem.Item) (*keys.PublicKey, error) {
+	b, err := item.TryBytes()
+	if err != nil || len(b) == 0 {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(b, elliptic.P256())
+}
+
+// itemToArrayOfBytes converts stack item into a slice of byte slices.
+// Contract methods return Null instead of an empty array, so Null
+// is converted to nil slice.
+func itemToArrayOfBytes(item stackitem.Item, err error) ([][]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := item.(stackitem.Null); ok {
+		return nil, nil
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	res := make([][]byte, len(arr))
+	for i := range arr {
+		res[i], err = arr[i].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,815 @@
+// Package netmap contains RPC wrappers for FrostFS Netmap contract.
+//
+// Wrappers follow the contract ABI described in netmap/config.yml: safe
+// methods are available via ContractReader, state-changing methods via
+// Contract, and notifications can be parsed from application logs with
+// *EventsFromApplicationLog functions.
+package netmap
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// NodeState is an enumeration for node states.
+type NodeState int64
+
+// Various Node states, see netmap.NodeState in the contract.
+const (
+	_ NodeState = iota
+
+	// NodeStateOnline stands for nodes that are in full network and
+	// operational availability.
+	NodeStateOnline
+
+	// NodeStateOffline stands for nodes that are in network unavailability.
+	NodeStateOffline
+
+	// NodeStateMaintenance stands for nodes under maintenance with partial
+	// network availability.
+	NodeStateMaintenance
+)
+
+// Node groups data related to FrostFS storage nodes registered in the FrostFS
+// network.
+type Node struct {
+	// Information about the node encoded according to the FrostFS binary
+	// protocol.
+	BLOB []byte
+
+	// Current node state.
+	State NodeState
+}
+
+// NetmapDiff represents changes of the network map between two epochs,
+// see netmap.NetmapDiff in the contract.
+type NetmapDiff struct {
+	Added   []*Node
+	Removed []*Node
+	Changed []*Node
+}
+
+// ConfigRecord represents a single FrostFS configuration record.
+type ConfigRecord struct {
+	Key   []byte
+	Value []byte
+}
+
+// IRNode represents a single Inner Ring node.
+type IRNode struct {
+	PublicKey *keys.PublicKey
+}
+
+// NewEpochEvent represents "NewEpoch" event emitted by the contract.
+type NewEpochEvent struct {
+	Epoch *big.Int
+}
+
+// AddPeerSuccessEvent represents "AddPeerSuccess" event emitted by the contract.
+type AddPeerSuccessEvent struct {
+	PublicKey *keys.PublicKey
+}
+
+// UpdateStateSuccessEvent represents "UpdateStateSuccess" event emitted by the contract.
+type UpdateStateSuccessEvent struct {
+	PublicKey *keys.PublicKey
+	State     NodeState
+}
+
+// PeerEvictedEvent represents "PeerEvicted" event emitted by the contract.
+type PeerEvictedEvent struct {
+	PublicKey *keys.PublicKey
+}
+
+// ErrConfigNotFound is returned by typed configuration getters if the requested
+// key is missing in the contract storage.
+var ErrConfigNotFound = errors.New("configuration value not found")
+
+// Invoker is used by ContractReader to call various safe methods.
+type Invoker interface {
+	Call(contract util.Uint160, operation string, params ...interface{}) (*result.Invoke, error)
+	CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...interface{}) (*result.Invoke, error)
+	TerminateSession(sessionID uuid.UUID) error
+	TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error)
+}
+
+// Actor is used by Contract to call state-changing methods.
+type Actor interface {
+	Invoker
+
+	MakeCall(contract util.Uint160, method string, params ...interface{}) (*transaction.Transaction, error)
+	MakeRun(script []byte) (*transaction.Transaction, error)
+	MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...interface{}) (*transaction.Transaction, error)
+	MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error)
+	SendCall(contract util.Uint160, method string, params ...interface{}) (util.Uint256, uint32, error)
+	SendRun(script []byte) (util.Uint256, uint32, error)
+}
+
+// ContractReader implements safe contract methods.
+type ContractReader struct {
+	invoker Invoker
+	hash    util.Uint160
+}
+
+// Contract implements all contract methods.
+type Contract struct {
+	ContractReader
+	actor Actor
+	hash  util.Uint160
+}
+
+// NewReader creates an instance of ContractReader using the given contract
+// hash and Invoker.
+func NewReader(invoker Invoker, hash util.Uint160) *ContractReader {
+	return &ContractReader{invoker, hash}
+}
+
+// New creates an instance of Contract using the given contract hash and Actor.
+func New(actor Actor, hash util.Uint160) *Contract {
+	return &Contract{ContractReader{actor, hash}, actor, hash}
+}
+
+// InnerRingList invokes `innerRingList` method of contract.
+func (c *ContractReader) InnerRingList() ([]*IRNode, error) {
+	arr, err := itemToArray(unwrap.Item(c.invoker.Call(c.hash, "innerRingList")))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*IRNode, len(arr))
+	for i := range arr {
+		fields, ok := arr[i].Value().([]stackitem.Item)
+		if !ok || len(fields) != 1 {
+			return nil, fmt.Errorf("item %d: wrong structure", i)
+		}
+		res[i] = new(IRNode)
+		res[i].PublicKey, err = itemToPublicKey(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// Epoch invokes `epoch` method of contract.
+func (c *ContractReader) Epoch() (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "epoch"))
+}
+
+// LastEpochBlock invokes `lastEpochBlock` method of contract.
+func (c *ContractReader) LastEpochBlock() (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "lastEpochBlock"))
+}
+
+// EpochBlock invokes `epochBlock` method of contract.
+func (c *ContractReader) EpochBlock(epoch *big.Int) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "epochBlock", epoch))
+}
+
+// EpochTime invokes `epochTime` method of contract.
+func (c *ContractReader) EpochTime(epoch *big.Int) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "epochTime", epoch))
+}
+
+// EpochByBlock invokes `epochByBlock` method of contract.
+func (c *ContractReader) EpochByBlock(height *big.Int) (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "epochByBlock", height))
+}
+
+// Netmap invokes `netmap` method of contract.
+func (c *ContractReader) Netmap() ([]*Node, error) {
+	return itemToNodes(unwrap.Item(c.invoker.Call(c.hash, "netmap")))
+}
+
+// NetmapCandidates invokes `netmapCandidates` method of contract.
+func (c *ContractReader) NetmapCandidates() ([]*Node, error) {
+	return itemToNodes(unwrap.Item(c.invoker.Call(c.hash, "netmapCandidates")))
+}
+
+// Snapshot invokes `snapshot` method of contract.
+func (c *ContractReader) Snapshot(diff *big.Int) ([]*Node, error) {
+	return itemToNodes(unwrap.Item(c.invoker.Call(c.hash, "snapshot", diff)))
+}
+
+// SnapshotByEpoch invokes `snapshotByEpoch` method of contract.
+func (c *ContractReader) SnapshotByEpoch(epoch *big.Int) ([]*Node, error) {
+	return itemToNodes(unwrap.Item(c.invoker.Call(c.hash, "snapshotByEpoch", epoch)))
+}
+
+// SnapshotIterator invokes `snapshotIterator` method of contract.
+func (c *ContractReader) SnapshotIterator(diff *big.Int) (uuid.UUID, result.Iterator, error) {
+	return unwrap.SessionIterator(c.invoker.Call(c.hash, "snapshotIterator", diff))
+}
+
+// SnapshotIteratorExpanded is similar to SnapshotIterator (uses the same contract
+// method), but can be useful if the server used doesn't support sessions and
+// doesn't expand iterators. It creates a script that will get the specified
+// number of result items from the iterator right in the VM and return them to
+// you. It's only limited by VM stack and GAS available for RPC invocations.
+func (c *ContractReader) SnapshotIteratorExpanded(diff *big.Int, _numOfIteratorItems int) ([]*Node, error) {
+	return itemToNodes(unwrap.Item(c.invoker.CallAndExpandIterator(c.hash, "snapshotIterator", _numOfIteratorItems, diff)))
+}
+
+// SnapshotDiff invokes `snapshotDiff` method of contract.
+func (c *ContractReader) SnapshotDiff(epochA, epochB *big.Int) (*NetmapDiff, error) {
+	item, err := unwrap.Item(c.invoker.Call(c.hash, "snapshotDiff", epochA, epochB))
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(NetmapDiff)
+	if err := res.FromStackItem(item); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsInSnapshot invokes `isInSnapshot` method of contract.
+func (c *ContractReader) IsInSnapshot(diff *big.Int, publicKey *keys.PublicKey) (bool, error) {
+	return unwrap.Bool(c.invoker.Call(c.hash, "isInSnapshot", diff, publicKey.Bytes()))
+}
+
+// Config invokes `config` method of contract. Raw stack item is returned,
+// see ConfigInt, ConfigBool and ConfigBytes for typed getters.
+func (c *ContractReader) Config(key []byte) (stackitem.Item, error) {
+	return unwrap.Item(c.invoker.Call(c.hash, "config", key))
+}
+
+// ConfigTyped invokes `configTyped` method of contract. Raw stack item of
+// the type registered in the contract configuration schema is returned.
+func (c *ContractReader) ConfigTyped(key []byte) (stackitem.Item, error) {
+	return unwrap.Item(c.invoker.Call(c.hash, "configTyped", key))
+}
+
+// ConfigInt invokes `config` method of contract and decodes the value
+// as an integer. ErrConfigNotFound is returned if the key is missing.
+func (c *ContractReader) ConfigInt(key []byte) (*big.Int, error) {
+	item, err := c.configValue(key)
+	if err != nil {
+		return nil, err
+	}
+	return item.TryInteger()
+}
+
+// ConfigBool invokes `config` method of contract and decodes the value
+// as a boolean. ErrConfigNotFound is returned if the key is missing.
+func (c *ContractReader) ConfigBool(key []byte) (bool, error) {
+	item, err := c.configValue(key)
+	if err != nil {
+		return false, err
+	}
+	return item.TryBool()
+}
+
+// ConfigBytes invokes `config` method of contract and returns the value
+// as a byte slice. ErrConfigNotFound is returned if the key is missing.
+func (c *ContractReader) ConfigBytes(key []byte) ([]byte, error) {
+	item, err := c.configValue(key)
+	if err != nil {
+		return nil, err
+	}
+	return item.TryBytes()
+}
+
+func (c *ContractReader) configValue(key []byte) (stackitem.Item, error) {
+	item, err := c.Config(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := item.(stackitem.Null); ok {
+		return nil, ErrConfigNotFound
+	}
+	return item, nil
+}
+
+// ListConfig invokes `listConfig` method of contract.
+func (c *ContractReader) ListConfig() ([]*ConfigRecord, error) {
+	arr, err := itemToArray(unwrap.Item(c.invoker.Call(c.hash, "listConfig")))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ConfigRecord, len(arr))
+	for i := range arr {
+		fields, ok := arr[i].Value().([]stackitem.Item)
+		if !ok || len(fields) != 2 {
+			return nil, fmt.Errorf("item %d: wrong structure", i)
+		}
+		res[i] = new(ConfigRecord)
+		res[i].Key, err = fields[0].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("item %d, field Key: %w", i, err)
+		}
+		res[i].Value, err = fields[1].TryBytes()
+		if err != nil {
+			return nil, fmt.Errorf("item %d, field Value: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// Version invokes `version` method of contract.
+func (c *ContractReader) Version() (*big.Int, error) {
+	return unwrap.BigInt(c.invoker.Call(c.hash, "version"))
+}
+
+// AddPeer creates a transaction invoking `addPeer` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) AddPeer(nodeInfo []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "addPeer", nodeInfo)
+}
+
+// AddPeerTransaction creates a transaction invoking `addPeer` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) AddPeerTransaction(nodeInfo []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "addPeer", nodeInfo)
+}
+
+// AddPeerUnsigned creates a transaction invoking `addPeer` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) AddPeerUnsigned(nodeInfo []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "addPeer", nil, nodeInfo)
+}
+
+// AddPeerIR creates a transaction invoking `addPeerIR` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) AddPeerIR(nodeInfo []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "addPeerIR", nodeInfo)
+}
+
+// AddPeerIRTransaction creates a transaction invoking `addPeerIR` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) AddPeerIRTransaction(nodeInfo []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "addPeerIR", nodeInfo)
+}
+
+// AddPeerIRUnsigned creates a transaction invoking `addPeerIR` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) AddPeerIRUnsigned(nodeInfo []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "addPeerIR", nil, nodeInfo)
+}
+
+// UpdateState creates a transaction invoking `updateState` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) UpdateState(state NodeState, publicKey *keys.PublicKey) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "updateState", int64(state), publicKey.Bytes())
+}
+
+// UpdateStateTransaction creates a transaction invoking `updateState` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) UpdateStateTransaction(state NodeState, publicKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "updateState", int64(state), publicKey.Bytes())
+}
+
+// UpdateStateUnsigned creates a transaction invoking `updateState` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) UpdateStateUnsigned(state NodeState, publicKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "updateState", nil, int64(state), publicKey.Bytes())
+}
+
+// UpdateStateIR creates a transaction invoking `updateStateIR` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) UpdateStateIR(state NodeState, publicKey *keys.PublicKey) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "updateStateIR", int64(state), publicKey.Bytes())
+}
+
+// UpdateStateIRTransaction creates a transaction invoking `updateStateIR` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) UpdateStateIRTransaction(state NodeState, publicKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "updateStateIR", int64(state), publicKey.Bytes())
+}
+
+// UpdateStateIRUnsigned creates a transaction invoking `updateStateIR` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) UpdateStateIRUnsigned(state NodeState, publicKey *keys.PublicKey) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "updateStateIR", nil, int64(state), publicKey.Bytes())
+}
+
+// UpdateStateWithDeadline creates a transaction invoking `updateStateWithDeadline` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) UpdateStateWithDeadline(state NodeState, publicKey *keys.PublicKey, deadline *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "updateStateWithDeadline", int64(state), publicKey.Bytes(), deadline)
+}
+
+// UpdateStateWithDeadlineTransaction creates a transaction invoking `updateStateWithDeadline` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) UpdateStateWithDeadlineTransaction(state NodeState, publicKey *keys.PublicKey, deadline *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "updateStateWithDeadline", int64(state), publicKey.Bytes(), deadline)
+}
+
+// UpdateStateWithDeadlineUnsigned creates a transaction invoking `updateStateWithDeadline` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) UpdateStateWithDeadlineUnsigned(state NodeState, publicKey *keys.PublicKey, deadline *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "updateStateWithDeadline", nil, int64(state), publicKey.Bytes(), deadline)
+}
+
+// UpdateStateWithDeadlineIR creates a transaction invoking `updateStateWithDeadlineIR` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) UpdateStateWithDeadlineIR(state NodeState, publicKey *keys.PublicKey, deadline *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "updateStateWithDeadlineIR", int64(state), publicKey.Bytes(), deadline)
+}
+
+// UpdateStateWithDeadlineIRTransaction creates a transaction invoking `updateStateWithDeadlineIR` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) UpdateStateWithDeadlineIRTransaction(state NodeState, publicKey *keys.PublicKey, deadline *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "updateStateWithDeadlineIR", int64(state), publicKey.Bytes(), deadline)
+}
+
+// UpdateStateWithDeadlineIRUnsigned creates a transaction invoking `updateStateWithDeadlineIR` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) UpdateStateWithDeadlineIRUnsigned(state NodeState, publicKey *keys.PublicKey, deadline *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "updateStateWithDeadlineIR", nil, int64(state), publicKey.Bytes(), deadline)
+}
+
+// NewEpoch creates a transaction invoking `newEpoch` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) NewEpoch(epochNum *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "newEpoch", epochNum)
+}
+
+// NewEpochTransaction creates a transaction invoking `newEpoch` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) NewEpochTransaction(epochNum *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "newEpoch", epochNum)
+}
+
+// NewEpochUnsigned creates a transaction invoking `newEpoch` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) NewEpochUnsigned(epochNum *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "newEpoch", nil, epochNum)
+}
+
+// SetConfig creates a transaction invoking `setConfig` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) SetConfig(id []byte, key []byte, val []byte) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "setConfig", id, key, val)
+}
+
+// SetConfigTransaction creates a transaction invoking `setConfig` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) SetConfigTransaction(id []byte, key []byte, val []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "setConfig", id, key, val)
+}
+
+// SetConfigUnsigned creates a transaction invoking `setConfig` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) SetConfigUnsigned(id []byte, key []byte, val []byte) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "setConfig", nil, id, key, val)
+}
+
+// UpdateSnapshotCount creates a transaction invoking `updateSnapshotCount` method of the contract.
+// This transaction is signed and immediately sent to the network.
+// The values returned are its hash, ValidUntilBlock value and error if any.
+func (c *Contract) UpdateSnapshotCount(count *big.Int) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(c.hash, "updateSnapshotCount", count)
+}
+
+// UpdateSnapshotCountTransaction creates a transaction invoking `updateSnapshotCount` method of the contract.
+// This transaction is signed, but not sent to the network, instead it's
+// returned to the caller.
+func (c *Contract) UpdateSnapshotCountTransaction(count *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(c.hash, "updateSnapshotCount", count)
+}
+
+// UpdateSnapshotCountUnsigned creates a transaction invoking `updateSnapshotCount` method of the contract.
+// This transaction is not signed, it's simply returned to the caller.
+// Any fields of it that do not affect fees can be changed (ValidUntilBlock,
+// Nonce), fee values (NetworkFee, SystemFee) can be increased as well.
+func (c *Contract) UpdateSnapshotCountUnsigned(count *big.Int) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(c.hash, "updateSnapshotCount", nil, count)
+}
+
+// FromStackItem retrieves fields of Node from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *Node) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.BLOB, err = arr[0].TryBytes()
+	if err != nil {
+		return fmt.Errorf("field BLOB: %w", err)
+	}
+	state, err := arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field State: %w", err)
+	}
+	if !state.IsInt64() {
+		return errors.New("field State: not an int64")
+	}
+	res.State = NodeState(state.Int64())
+	return nil
+}
+
+// itemToNodes converts stack item into a slice of *Node.
+func itemToNodes(item stackitem.Item, err error) ([]*Node, error) {
+	arr, err := itemToArray(item, err)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Node, len(arr))
+	for i := range arr {
+		res[i] = new(Node)
+		if err := res[i].FromStackItem(arr[i]); err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem retrieves fields of NetmapDiff from the given stack item
+// and returns an error if the item has an unexpected structure.
+func (res *NetmapDiff) FromStackItem(item stackitem.Item) error {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	res.Added, err = itemToNodes(arr[0], nil)
+	if err != nil {
+		return fmt.Errorf("field Added: %w", err)
+	}
+	res.Removed, err = itemToNodes(arr[1], nil)
+	if err != nil {
+		return fmt.Errorf("field Removed: %w", err)
+	}
+	res.Changed, err = itemToNodes(arr[2], nil)
+	if err != nil {
+		return fmt.Errorf("field Changed: %w", err)
+	}
+	return nil
+}
+
+// NewEpochEventsFromApplicationLog retrieves a set of all emitted events
+// with "NewEpoch" name from the provided ApplicationLog.
+func NewEpochEventsFromApplicationLog(log *result.ApplicationLog) ([]*NewEpochEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*NewEpochEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "NewEpoch" {
+				continue
+			}
+			event := new(NewEpochEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize NewEpochEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to NewEpochEvent and
+// returns an error if so.
+func (e *NewEpochEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 1 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.Epoch, err = arr[0].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field Epoch: %w", err)
+	}
+	return nil
+}
+
+// AddPeerSuccessEventsFromApplicationLog retrieves a set of all emitted events
+// with "AddPeerSuccess" name from the provided ApplicationLog.
+func AddPeerSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*AddPeerSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*AddPeerSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "AddPeerSuccess" {
+				continue
+			}
+			event := new(AddPeerSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize AddPeerSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to AddPeerSuccessEvent and
+// returns an error if so.
+func (e *AddPeerSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 1 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.PublicKey, err = itemToPublicKey(arr[0])
+	if err != nil {
+		return fmt.Errorf("field PublicKey: %w", err)
+	}
+	return nil
+}
+
+// UpdateStateSuccessEventsFromApplicationLog retrieves a set of all emitted events
+// with "UpdateStateSuccess" name from the provided ApplicationLog.
+func UpdateStateSuccessEventsFromApplicationLog(log *result.ApplicationLog) ([]*UpdateStateSuccessEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*UpdateStateSuccessEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "UpdateStateSuccess" {
+				continue
+			}
+			event := new(UpdateStateSuccessEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize UpdateStateSuccessEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to UpdateStateSuccessEvent and
+// returns an error if so.
+func (e *UpdateStateSuccessEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 2 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.PublicKey, err = itemToPublicKey(arr[0])
+	if err != nil {
+		return fmt.Errorf("field PublicKey: %w", err)
+	}
+	state, err := arr[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("field State: %w", err)
+	}
+	if !state.IsInt64() {
+		return errors.New("field State: not an int64")
+	}
+	e.State = NodeState(state.Int64())
+	return nil
+}
+
+// PeerEvictedEventsFromApplicationLog retrieves a set of all emitted events
+// with "PeerEvicted" name from the provided ApplicationLog.
+func PeerEvictedEventsFromApplicationLog(log *result.ApplicationLog) ([]*PeerEvictedEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+
+	var res []*PeerEvictedEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "PeerEvicted" {
+				continue
+			}
+			event := new(PeerEvictedEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize PeerEvictedEvent from stackitem (execution #%d, event #%d): %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
+
+// FromStackItem converts provided stackitem.Array to PeerEvictedEvent and
+// returns an error if so.
+func (e *PeerEvictedEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 1 {
+		return errors.New("wrong number of structure elements")
+	}
+
+	var err error
+	e.PublicKey, err = itemToPublicKey(arr[0])
+	if err != nil {
+		return fmt.Errorf("field PublicKey: %w", err)
+	}
+	return nil
+}
+
+// itemToPublicKey converts stack item into *keys.PublicKey.
+func itemToPublicKey(item stackitem.Item) (*keys.PublicKey, error) {
+	b, err := item.TryBytes()
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(b, elliptic.P256())
+}
+
+// itemToArray converts stack item into a slice of stack items.
+// Contract methods may return Null instead of an empty array, so Null
+// is converted to nil slice.
+func itemToArray(item stackitem.Item, err error) ([]stackitem.Item, error) {
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := item.(stackitem.Null); ok {
+		return nil, nil
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	return arr, nil
+}
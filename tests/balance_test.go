@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
 )
 
 const balancePath = "../balance"
@@ -26,3 +27,29 @@ func deployBalanceContract(t *testing.T, e *neotest.Executor, addrNetmap, addrCo
 func balanceMint(t *testing.T, c *neotest.ContractInvoker, acc neotest.Signer, amount int64, details []byte) {
 	c.Invoke(t, stackitem.Null{}, "mint", acc.ScriptHash(), amount, details)
 }
+
+func TestBalanceLockFee(t *testing.T) {
+	c, cBal, _ := newContainerInvokerInternal(t, true)
+
+	acc := c.NewAccount(t)
+	cnt := dummyContainer(acc)
+	balanceMint(t, cBal, acc, containerFee*1, []byte{})
+
+	cAcc := c.WithSigners(acc)
+	h := cAcc.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+	aer := cAcc.CheckHalt(t, h)
+
+	var locked bool
+	for _, ev := range aer.Events {
+		if ev.ScriptHash != cBal.Hash {
+			continue
+		}
+
+		// Lock notification makes Inner Ring issue a cheque in the mainchain.
+		require.NotEqual(t, "Lock", ev.Name)
+		if ev.Name == "FeeLocked" {
+			locked = true
+		}
+	}
+	require.True(t, locked)
+}
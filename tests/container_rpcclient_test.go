@@ -0,0 +1,173 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	cnrclient "github.com/TrueCloudLab/frostfs-contract/rpcclient/container"
+	"github.com/mr-tron/base58"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func applicationLog(t *testing.T, c *neotest.ContractInvoker, h util.Uint256) *result.ApplicationLog {
+	aer := c.CheckHalt(t, h)
+	return &result.ApplicationLog{
+		Container:  h,
+		Executions: []state.Execution{aer.Execution},
+	}
+}
+
+// signedContainer is like dummyContainer, but uses a valid public key,
+// so it can be decoded by RPC wrappers.
+func signedContainer(owner neotest.Signer) testContainer {
+	cnt := dummyContainer(owner)
+	cnt.pub = owner.(neotest.SingleSigner).Account().PrivateKey().PublicKey().Bytes()
+	return cnt
+}
+
+func TestContainerRPCClient(t *testing.T) {
+	c, cBal, _ := newContainerInvoker(t)
+	inv := newTestInvoker(t, c.Executor)
+	reader := cnrclient.NewReader(inv, c.Hash)
+
+	count, err := reader.Count()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count.Int64())
+
+	list, err := reader.List(nil)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	acc := c.NewAccount(t)
+	cnt := signedContainer(acc)
+	balanceMint(t, cBal, acc, containerFee*1, []byte{})
+	h := c.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+
+	t.Run("PutSuccess event", func(t *testing.T) {
+		events, err := cnrclient.PutSuccessEventsFromApplicationLog(applicationLog(t, c, h))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, util.Uint256(cnt.id), events[0].ContainerID)
+		require.Equal(t, cnt.pub, events[0].PublicKey.Bytes())
+	})
+
+	t.Run("get", func(t *testing.T) {
+		actual, err := reader.Get(cnt.id[:])
+		require.NoError(t, err)
+		require.Equal(t, cnt.value, actual.Value)
+		require.Equal(t, cnt.sig, actual.Sig)
+		require.Equal(t, cnt.pub, actual.Pub.Bytes())
+		require.Equal(t, cnt.token, actual.Token)
+
+		id := cnt.id
+		id[0] ^= 0xFF
+		_, err = reader.Get(id[:])
+		require.Error(t, err)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		expected, _ := base58.Decode(address.Uint160ToString(acc.ScriptHash()))
+		owner, err := reader.Owner(cnt.id[:])
+		require.NoError(t, err)
+		require.Equal(t, expected, owner)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		count, err := reader.Count()
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count.Int64())
+
+		list, err := reader.List(nil)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{cnt.id[:]}, list)
+
+		list, err = reader.ContainersOfExpanded(nil, 10)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{cnt.id[:]}, list)
+
+		session, iter, err := reader.ContainersOf(nil)
+		require.NoError(t, err)
+		items, err := inv.TraverseIterator(session, &iter, 10)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, cnt.id[:], items[0].Value())
+	})
+
+	t.Run("eACL", func(t *testing.T) {
+		e, err := reader.EACL(cnt.id[:])
+		require.NoError(t, err)
+		require.Empty(t, e.Value)
+		require.Nil(t, e.Pub)
+
+		e1 := dummyEACL(cnt.id)
+		e1.pub = cnt.pub
+		h := c.Invoke(t, stackitem.Null{}, "setEACL", e1.value, e1.sig, e1.pub, e1.token)
+
+		events, err := cnrclient.SetEACLSuccessEventsFromApplicationLog(applicationLog(t, c, h))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, cnt.id[:], events[0].ContainerID)
+		require.Equal(t, cnt.pub, events[0].PublicKey.Bytes())
+
+		e, err = reader.EACL(cnt.id[:])
+		require.NoError(t, err)
+		require.Equal(t, e1.value, e.Value)
+		require.Equal(t, e1.sig, e.Sig)
+		require.Equal(t, e1.pub, e.Pub.Bytes())
+		require.Equal(t, e1.token, e.Token)
+	})
+
+	t.Run("container sizes", func(t *testing.T) {
+		ids, err := reader.ListContainerSizes(big.NewInt(1))
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	})
+
+	t.Run("DeleteSuccess event", func(t *testing.T) {
+		h := c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+
+		events, err := cnrclient.DeleteSuccessEventsFromApplicationLog(applicationLog(t, c, h))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, cnt.id[:], events[0].ContainerID)
+
+		_, err = reader.Get(cnt.id[:])
+		require.Error(t, err)
+	})
+
+	t.Run("nil application log", func(t *testing.T) {
+		_, err := cnrclient.PutSuccessEventsFromApplicationLog(nil)
+		require.Error(t, err)
+	})
+}
+
+func TestContainerRPCClientSizes(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+	reader := cnrclient.NewReader(newTestInvoker(t, c.Executor), c.Hash)
+
+	_, cnt := addContainer(t, c, cBal)
+	node := newStorageNode(t, c)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", node.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	c.WithSigners(node.signer).Invoke(t, stackitem.Null{}, "putContainerSize",
+		int64(2), cnt.id[:], int64(123), node.pub)
+
+	ids, err := reader.ListContainerSizes(big.NewInt(2))
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+
+	sizes, err := reader.GetContainerSize(ids[0])
+	require.NoError(t, err)
+	require.Equal(t, cnt.id[:], sizes.CID)
+	require.Len(t, sizes.Estimations, 1)
+	require.Equal(t, node.pub, sizes.Estimations[0].From.Bytes())
+	require.Equal(t, int64(123), sizes.Estimations[0].Size.Int64())
+}
@@ -3,18 +3,25 @@ package tests
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/binary"
 	"math/big"
 	"path"
+	"sort"
 	"testing"
 
 	"github.com/TrueCloudLab/frostfs-contract/common"
 	"github.com/TrueCloudLab/frostfs-contract/container"
 	"github.com/TrueCloudLab/frostfs-contract/nns"
+	"github.com/TrueCloudLab/frostfs-contract/subnet"
 	"github.com/mr-tron/base58"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
@@ -27,13 +34,18 @@ const (
 )
 
 func deployContainerContract(t *testing.T, e *neotest.Executor, addrNetmap, addrBalance, addrNNS util.Uint160) util.Uint160 {
-	args := make([]interface{}, 6)
-	args[0] = int64(0)
+	return deployContainerContractInternal(t, e, false, addrNetmap, addrBalance, util.Uint160{}, addrNNS, util.Uint160{})
+}
+
+func deployContainerContractInternal(t *testing.T, e *neotest.Executor, notaryDisabled bool, addrNetmap, addrBalance, addrID, addrNNS, addrSubnet util.Uint160) util.Uint160 {
+	args := make([]interface{}, 7)
+	args[0] = notaryDisabled
 	args[1] = addrNetmap
 	args[2] = addrBalance
-	args[3] = util.Uint160{} // not needed for now
+	args[3] = addrID
 	args[4] = addrNNS
 	args[5] = "frostfs"
+	args[6] = addrSubnet
 
 	c := neotest.CompileFile(t, e.CommitteeHash, containerPath, path.Join(containerPath, "config.yml"))
 	e.DeployContract(t, c, args)
@@ -41,19 +53,26 @@ func deployContainerContract(t *testing.T, e *neotest.Executor, addrNetmap, addr
 }
 
 func newContainerInvoker(t *testing.T) (*neotest.ContractInvoker, *neotest.ContractInvoker, *neotest.ContractInvoker) {
+	return newContainerInvokerInternal(t, false)
+}
+
+func newContainerInvokerInternal(t *testing.T, notaryDisabled bool) (*neotest.ContractInvoker, *neotest.ContractInvoker, *neotest.ContractInvoker) {
 	e := newExecutor(t)
 
 	ctrNNS := neotest.CompileFile(t, e.CommitteeHash, nnsPath, path.Join(nnsPath, "config.yml"))
 	ctrNetmap := neotest.CompileFile(t, e.CommitteeHash, netmapPath, path.Join(netmapPath, "config.yml"))
 	ctrBalance := neotest.CompileFile(t, e.CommitteeHash, balancePath, path.Join(balancePath, "config.yml"))
 	ctrContainer := neotest.CompileFile(t, e.CommitteeHash, containerPath, path.Join(containerPath, "config.yml"))
+	ctrFrostFSID := neotest.CompileFile(t, e.CommitteeHash, frostfsidPath, path.Join(frostfsidPath, "config.yml"))
 
 	e.DeployContract(t, ctrNNS, nil)
 	deployNetmapContract(t, e, ctrBalance.Hash, ctrContainer.Hash,
 		container.RegistrationFeeKey, int64(containerFee),
 		container.AliasFeeKey, int64(containerAliasFee))
 	deployBalanceContract(t, e, ctrNetmap.Hash, ctrContainer.Hash)
-	deployContainerContract(t, e, ctrNetmap.Hash, ctrBalance.Hash, ctrNNS.Hash)
+	addrSubnet := deploySubnetContract(t, e)
+	deployContainerContractInternal(t, e, notaryDisabled, ctrNetmap.Hash, ctrBalance.Hash, ctrFrostFSID.Hash, ctrNNS.Hash, addrSubnet)
+	deployFrostFSIDContract(t, e, ctrNetmap.Hash, ctrContainer.Hash)
 	return e.CommitteeInvoker(ctrContainer.Hash), e.CommitteeInvoker(ctrBalance.Hash), e.CommitteeInvoker(ctrNetmap.Hash)
 }
 
@@ -81,6 +100,71 @@ func dummyContainer(owner neotest.Signer) testContainer {
 	}
 }
 
+// containerWithAttributes returns a stable marshaled V2 container with
+// the specified attributes, which are passed as key-value pairs.
+func containerWithAttributes(owner neotest.Signer, attrs ...string) testContainer {
+	return containerWithPolicy(owner, nil, attrs...)
+}
+
+// containerWithPolicy is similar to containerWithAttributes, but also sets
+// stable marshaled placement policy if it is not nil.
+func containerWithPolicy(owner neotest.Signer, policy []byte, attrs ...string) testContainer {
+	ownerID, _ := base58.Decode(address.Uint160ToString(owner.ScriptHash()))
+
+	value := []byte{0x0A, 0x00, 0x12, 0x1B, 0x0A, 0x19} // empty version, owner prefix
+	value = append(value, ownerID...)
+	value = appendProtoBytes(value, 3, randomBytes(16)) // nonce
+	value = append(value, 4<<3)                         // basic ACL
+	value = appendProtoVarint(value, 0x1FBFBFFF)
+	for i := 0; i < len(attrs); i += 2 {
+		a := appendProtoBytes(nil, 1, []byte(attrs[i]))
+		a = appendProtoBytes(a, 2, []byte(attrs[i+1]))
+		value = appendProtoBytes(value, 5, a)
+	}
+	if policy != nil {
+		value = appendProtoBytes(value, 6, policy)
+	}
+
+	return testContainer{
+		id:    sha256.Sum256(value),
+		value: value,
+		sig:   randomBytes(64),
+		pub:   randomBytes(33),
+		token: randomBytes(42),
+	}
+}
+
+// placementPolicy returns stable marshaled placement policy with the specified
+// replica counts and subnet ID.
+func placementPolicy(subnetID uint32, replicas ...uint64) []byte {
+	var policy []byte
+	for i := range replicas {
+		policy = appendProtoBytes(policy, 1, appendProtoVarint([]byte{1 << 3}, replicas[i]))
+	}
+	if subnetID != 0 {
+		policy = appendProtoBytes(policy, 5, marshalSubnetID(subnetID))
+	}
+	return policy
+}
+
+func marshalSubnetID(id uint32) []byte {
+	data := make([]byte, 5)
+	data[0] = 1<<3 | 5 // fixed32
+	binary.LittleEndian.PutUint32(data[1:], id)
+	return data
+}
+
+func appendProtoBytes(buf []byte, field int, data []byte) []byte {
+	buf = append(buf, byte(field<<3|2))
+	buf = appendProtoVarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+func appendProtoVarint(buf []byte, v uint64) []byte {
+	b := make([]byte, binary.MaxVarintLen64)
+	return append(buf, b[:binary.PutUvarint(b, v)]...)
+}
+
 func TestContainerCount(t *testing.T) {
 	c, cBal, _ := newContainerInvoker(t)
 
@@ -92,29 +176,47 @@ func TestContainerCount(t *testing.T) {
 		require.Equal(t, int64(expected), bi.Int64())
 	}
 
+	checkCountByOwner := func(t *testing.T, acc neotest.Signer, expected int64) {
+		owner, _ := base58.Decode(address.Uint160ToString(acc.ScriptHash()))
+		c.Invoke(t, expected, "countByOwner", owner)
+	}
+
 	checkCount(t, 0)
 	acc1, cnt1 := addContainer(t, c, cBal)
 	checkCount(t, 1)
+	checkCountByOwner(t, acc1, 1)
 
-	_, cnt2 := addContainer(t, c, cBal)
+	acc2, cnt2 := addContainer(t, c, cBal)
 	checkCount(t, 2)
+	checkCountByOwner(t, acc2, 1)
 
 	// Same owner.
 	cnt3 := dummyContainer(acc1)
 	balanceMint(t, cBal, acc1, containerFee*1, []byte{})
 	c.Invoke(t, stackitem.Null{}, "put", cnt3.value, cnt3.sig, cnt3.pub, cnt3.token)
 	checkContainerList(t, c, [][]byte{cnt1.id[:], cnt2.id[:], cnt3.id[:]})
+	checkCount(t, 3)
+	checkCountByOwner(t, acc1, 2)
 
 	c.Invoke(t, stackitem.Null{}, "delete", cnt1.id[:], cnt1.sig, cnt1.token)
 	checkCount(t, 2)
+	checkCountByOwner(t, acc1, 1)
 	checkContainerList(t, c, [][]byte{cnt2.id[:], cnt3.id[:]})
 
+	t.Run("delete missing container", func(t *testing.T) {
+		c.Invoke(t, stackitem.Null{}, "delete", cnt1.id[:], cnt1.sig, cnt1.token)
+		checkCount(t, 2)
+		checkCountByOwner(t, acc1, 1)
+	})
+
 	c.Invoke(t, stackitem.Null{}, "delete", cnt2.id[:], cnt2.sig, cnt2.token)
 	checkCount(t, 1)
+	checkCountByOwner(t, acc2, 0)
 	checkContainerList(t, c, [][]byte{cnt3.id[:]})
 
 	c.Invoke(t, stackitem.Null{}, "delete", cnt3.id[:], cnt3.sig, cnt3.token)
 	checkCount(t, 0)
+	checkCountByOwner(t, acc1, 0)
 	checkContainerList(t, c, [][]byte{})
 }
 
@@ -161,6 +263,136 @@ func checkContainerList(t *testing.T, c *neotest.ContractInvoker, expected [][]b
 
 }
 
+func TestContainersOfPaged(t *testing.T) {
+	c, cBal, _ := newContainerInvoker(t)
+
+	const (
+		containerCount = 2000
+		batchSize      = 100
+		pageSize       = 128
+	)
+
+	accs := []neotest.Signer{c.NewAccount(t), c.NewAccount(t)}
+	for i := range accs {
+		balanceMint(t, cBal, accs[i], containerFee*containerCount, []byte{})
+	}
+
+	all := make([][]byte, 0, containerCount)
+	byOwner := make([][][]byte, len(accs))
+	for i := 0; i < containerCount; i += batchSize {
+		txs := make([]*transaction.Transaction, 0, batchSize)
+		for j := i; j < i+batchSize; j++ {
+			k := 0
+			if j%4 == 0 {
+				k = 1
+			}
+
+			cnt := dummyContainer(accs[k])
+			all = append(all, cnt.id[:])
+			byOwner[k] = append(byOwner[k], cnt.id[:])
+			txs = append(txs, c.PrepareInvoke(t, "put", cnt.value, cnt.sig, cnt.pub, cnt.token))
+		}
+		c.AddNewBlock(t, txs...)
+		for _, tx := range txs {
+			c.CheckHalt(t, tx.Hash(), stackitem.Null{})
+		}
+	}
+
+	checkPages := func(t *testing.T, owner []byte, expected [][]byte) {
+		sort.Slice(expected, func(i, j int) bool {
+			return bytes.Compare(expected[i], expected[j]) == -1
+		})
+
+		var cursor []byte
+		actual := [][]byte{}
+		for {
+			s, err := c.TestInvoke(t, "containersOfPaged", owner, cursor, pageSize)
+			require.NoError(t, err)
+
+			arr, ok := s.Pop().Value().([]stackitem.Item)
+			require.True(t, ok)
+			require.True(t, len(arr) <= pageSize)
+
+			for i := range arr {
+				id, err := arr[i].TryBytes()
+				require.NoError(t, err)
+				actual = append(actual, id)
+			}
+
+			if len(arr) < pageSize {
+				break
+			}
+			cursor = actual[len(actual)-1]
+		}
+		require.Equal(t, expected, actual)
+	}
+
+	t.Run("all containers", func(t *testing.T) {
+		checkPages(t, nil, all)
+	})
+	t.Run("by owner", func(t *testing.T) {
+		for i := range accs {
+			owner, _ := base58.Decode(address.Uint160ToString(accs[i].ScriptHash()))
+			checkPages(t, owner, byOwner[i])
+		}
+	})
+	t.Run("missing owner", func(t *testing.T) {
+		owner, _ := base58.Decode(address.Uint160ToString(c.NewAccount(t).ScriptHash()))
+		checkPages(t, owner, [][]byte{})
+	})
+	t.Run("arbitrary cursor", func(t *testing.T) {
+		sort.Slice(all, func(i, j int) bool {
+			return bytes.Compare(all[i], all[j]) == -1
+		})
+
+		for _, i := range []int{0, 1, containerCount / 2, containerCount - pageSize/2} {
+			cursor := append([]byte{}, all[i]...)
+			cursor[len(cursor)-1]++
+
+			j := sort.Search(len(all), func(j int) bool {
+				return bytes.Compare(all[j], cursor) > 0
+			})
+			expected := all[j:]
+			if len(expected) > pageSize {
+				expected = expected[:pageSize]
+			}
+
+			s, err := c.TestInvoke(t, "containersOfPaged", nil, cursor, pageSize)
+			require.NoError(t, err)
+
+			arr, ok := s.Pop().Value().([]stackitem.Item)
+			require.True(t, ok)
+
+			actual := make([][]byte, 0, len(arr))
+			for k := range arr {
+				id, err := arr[k].TryBytes()
+				require.NoError(t, err)
+				actual = append(actual, id)
+			}
+			require.Equal(t, expected, actual)
+		}
+	})
+	t.Run("cost doesn't depend on the cursor", func(t *testing.T) {
+		gasConsumed := func(cursor []byte) int64 {
+			tx := c.PrepareInvoke(t, "containersOfPaged", nil, cursor, pageSize)
+			c.AddNewBlock(t, tx)
+			return c.CheckHalt(t, tx.Hash()).GasConsumed
+		}
+
+		sort.Slice(all, func(i, j int) bool {
+			return bytes.Compare(all[i], all[j]) == -1
+		})
+
+		first := gasConsumed(all[pageSize])
+		last := gasConsumed(all[len(all)-2*pageSize])
+		require.Less(t, last, 2*first)
+	})
+	t.Run("invalid page size", func(t *testing.T) {
+		c.InvokeFail(t, "invalid page size", "containersOfPaged", nil, nil, 0)
+		c.InvokeFail(t, "invalid page size", "containersOfPaged", nil, nil, container.MaxPageSize+1)
+	})
+}
+
 func TestContainerPut(t *testing.T) {
 	c, cBal, _ := newContainerInvoker(t)
 
@@ -177,11 +409,16 @@ func TestContainerPut(t *testing.T) {
 
 	c.Invoke(t, stackitem.Null{}, "put", putArgs...)
 
+	t.Run("already exists", func(t *testing.T) {
+		c.InvokeFail(t, container.AlreadyExistsError, "put", putArgs...)
+	})
+
 	t.Run("with nice names", func(t *testing.T) {
 		ctrNNS := neotest.CompileFile(t, c.CommitteeHash, nnsPath, path.Join(nnsPath, "config.yml"))
 		nnsHash := ctrNNS.Hash
 
 		balanceMint(t, cBal, acc, containerFee*1, []byte{})
+		cnt := dummyContainer(acc)
 
 		putArgs := []interface{}{cnt.value, cnt.sig, cnt.pub, cnt.token, "mycnt", ""}
 		t.Run("no fee for alias", func(t *testing.T) {
@@ -198,12 +435,18 @@ func TestContainerPut(t *testing.T) {
 		cNNS.Invoke(t, expected, "resolve", "mycnt.frostfs", int64(nns.TXT))
 
 		t.Run("name is already taken", func(t *testing.T) {
+			cnt := dummyContainer(acc)
+			putArgs := []interface{}{cnt.value, cnt.sig, cnt.pub, cnt.token, "mycnt", ""}
 			c.InvokeFail(t, "name is already taken", "putNamed", putArgs...)
 		})
 
 		c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
 		cNNS.Invoke(t, stackitem.Null{}, "resolve", "mycnt.frostfs", int64(nns.TXT))
 
+		t.Run("recently deleted", func(t *testing.T) {
+			c.InvokeFail(t, container.AlreadyExistsError, "putNamed", putArgs...)
+		})
+
 		t.Run("register in advance", func(t *testing.T) {
 			cnt.value[len(cnt.value)-1] = 10
 			cnt.id = sha256.Sum256(cnt.value)
@@ -229,6 +472,238 @@ func TestContainerPut(t *testing.T) {
 	})
 }
 
+func TestContainerAlias(t *testing.T) {
+	c, cBal, _ := newContainerInvoker(t)
+
+	ctrNNS := neotest.CompileFile(t, c.CommitteeHash, nnsPath, path.Join(nnsPath, "config.yml"))
+	cNNS := c.CommitteeInvoker(ctrNNS.Hash)
+
+	acc, cnt := addContainer(t, c, cBal)
+	c.Invoke(t, stackitem.Make(""), "alias", cnt.id[:])
+
+	expected := stackitem.NewArray([]stackitem.Item{
+		stackitem.NewByteArray([]byte(base58.Encode(cnt.id[:]))),
+	})
+
+	setArgs := []interface{}{cnt.id[:], "mycnt", "", cnt.sig, cnt.token}
+	c.InvokeFail(t, "insufficient balance to set container alias", "setAlias", setArgs...)
+
+	balanceMint(t, cBal, acc, containerAliasFee*1, []byte{})
+
+	cAcc := c.WithSigners(acc)
+	cAcc.InvokeFail(t, common.ErrAlphabetWitnessFailed, "setAlias", setArgs...)
+
+	c.Invoke(t, stackitem.Null{}, "setAlias", setArgs...)
+	c.Invoke(t, stackitem.Make("mycnt.frostfs"), "alias", cnt.id[:])
+	cNNS.Invoke(t, expected, "resolve", "mycnt.frostfs", int64(nns.TXT))
+	cBal.Invoke(t, stackitem.Make(0), "balanceOf", acc.ScriptHash())
+
+	t.Run("missing container", func(t *testing.T) {
+		id := cnt.id
+		id[0] ^= 0xFF
+
+		c.InvokeFail(t, container.NotFoundError, "setAlias", id[:], "other", "", cnt.sig, cnt.token)
+		c.InvokeFail(t, container.NotFoundError, "removeAlias", id[:], cnt.sig, cnt.token)
+		c.InvokeFail(t, container.NotFoundError, "alias", id[:])
+	})
+
+	t.Run("name is already taken", func(t *testing.T) {
+		acc, cnt := addContainer(t, c, cBal)
+		balanceMint(t, cBal, acc, containerAliasFee*1, []byte{})
+		c.InvokeFail(t, "name is already taken", "setAlias", cnt.id[:], "mycnt", "", cnt.sig, cnt.token)
+	})
+
+	balanceMint(t, cBal, acc, containerAliasFee*1, []byte{})
+	c.Invoke(t, stackitem.Null{}, "setAlias", cnt.id[:], "newcnt", "", cnt.sig, cnt.token)
+	c.Invoke(t, stackitem.Make("newcnt.frostfs"), "alias", cnt.id[:])
+	cNNS.Invoke(t, stackitem.Null{}, "resolve", "mycnt.frostfs", int64(nns.TXT))
+	cNNS.Invoke(t, expected, "resolve", "newcnt.frostfs", int64(nns.TXT))
+	c.InvokeFail(t, container.NotFoundError, "containerByName", "mycnt.frostfs")
+	checkContainerByName(t, c, "newcnt.frostfs", cnt.id[:])
+
+	cAcc.InvokeFail(t, common.ErrAlphabetWitnessFailed, "removeAlias", cnt.id[:], cnt.sig, cnt.token)
+	c.Invoke(t, stackitem.Null{}, "removeAlias", cnt.id[:], cnt.sig, cnt.token)
+	c.Invoke(t, stackitem.Make(""), "alias", cnt.id[:])
+	cNNS.Invoke(t, stackitem.Null{}, "resolve", "newcnt.frostfs", int64(nns.TXT))
+	c.InvokeFail(t, container.NotFoundError, "containerByName", "newcnt.frostfs")
+	c.InvokeFail(t, "container has no alias", "removeAlias", cnt.id[:], cnt.sig, cnt.token)
+
+	t.Run("removed with the container", func(t *testing.T) {
+		balanceMint(t, cBal, acc, containerAliasFee*1, []byte{})
+		c.Invoke(t, stackitem.Null{}, "setAlias", cnt.id[:], "mycnt", "", cnt.sig, cnt.token)
+		cNNS.Invoke(t, expected, "resolve", "mycnt.frostfs", int64(nns.TXT))
+
+		c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+		cNNS.Invoke(t, stackitem.Null{}, "resolve", "mycnt.frostfs", int64(nns.TXT))
+	})
+}
+
+func TestContainerByName(t *testing.T) {
+	c, cBal, _ := newContainerInvoker(t)
+
+	acc := c.NewAccount(t)
+	cnt := dummyContainer(acc)
+	balanceMint(t, cBal, acc, (containerFee+containerAliasFee)*1, []byte{})
+
+	c.InvokeFail(t, container.NotFoundError, "containerByName", "mycnt.frostfs")
+
+	c.Invoke(t, stackitem.Null{}, "putNamed", cnt.value, cnt.sig, cnt.pub, cnt.token, "mycnt", "")
+	checkContainerByName(t, c, "mycnt.frostfs", cnt.id[:])
+	c.InvokeFail(t, container.NotFoundError, "containerByName", "mycnt")
+
+	c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+	c.InvokeFail(t, container.NotFoundError, "containerByName", "mycnt.frostfs")
+}
+
+func checkContainerByName(t *testing.T, c *neotest.ContractInvoker, domain string, expected []byte) {
+	s, err := c.TestInvoke(t, "containerByName", domain)
+	require.NoError(t, err)
+	actual, err := s.Pop().Item().TryBytes()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
+
+func TestContainerFeeDistribution(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	getBalance := func(t *testing.T, method string, args ...interface{}) int64 {
+		s, err := cBal.TestInvoke(t, method, args...)
+		require.NoError(t, err)
+		return s.Pop().BigInt().Int64()
+	}
+	setConfig := func(key string, value interface{}) {
+		cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), key, value)
+	}
+
+	acc := c.NewAccount(t)
+	cnt := dummyContainer(acc)
+	putArgs := []interface{}{cnt.value, cnt.sig, cnt.pub, cnt.token}
+	balanceMint(t, cBal, acc, containerFee*1, []byte{})
+
+	setConfig(container.FeeAlphabetShareKey, int64(60))
+	setConfig(container.FeeBurnShareKey, int64(50))
+	c.InvokeFail(t, "invalid container fee distribution", "put", putArgs...)
+
+	setConfig(container.FeeBurnShareKey, int64(20))
+	c.InvokeFail(t, "container fee treasury is not set", "put", putArgs...)
+
+	treasury := c.NewAccount(t)
+	setConfig(container.FeeTreasuryKey, treasury.ScriptHash())
+	setConfig(container.FeeAlphabetShareKey, int64(50))
+
+	ir := c.Committee.(neotest.MultiSigner).Single(0).ScriptHash()
+	irBalance := getBalance(t, "balanceOf", ir)
+	supply := getBalance(t, "totalSupply")
+
+	c.Invoke(t, stackitem.Null{}, "put", putArgs...)
+
+	require.Equal(t, int64(0), getBalance(t, "balanceOf", acc.ScriptHash()))
+	require.Equal(t, irBalance+containerFee*50/100, getBalance(t, "balanceOf", ir))
+	require.Equal(t, int64(containerFee*30/100), getBalance(t, "balanceOf", treasury.ScriptHash()))
+	require.Equal(t, supply-containerFee*20/100, getBalance(t, "totalSupply"))
+
+	t.Run("without treasury share", func(t *testing.T) {
+		setConfig(container.FeeBurnShareKey, int64(50))
+
+		acc := c.NewAccount(t)
+		cnt := dummyContainer(acc)
+		balanceMint(t, cBal, acc, containerFee*1, []byte{})
+
+		irBalance := getBalance(t, "balanceOf", ir)
+		supply := getBalance(t, "totalSupply")
+
+		c.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+
+		require.Equal(t, int64(0), getBalance(t, "balanceOf", acc.ScriptHash()))
+		require.Equal(t, irBalance+containerFee*50/100, getBalance(t, "balanceOf", ir))
+		require.Equal(t, int64(containerFee*30/100), getBalance(t, "balanceOf", treasury.ScriptHash()))
+		require.Equal(t, supply-containerFee*50/100, getBalance(t, "totalSupply"))
+	})
+}
+
+func TestContainerPutNotaryDisabled(t *testing.T) {
+	c, cBal, cNm := newContainerInvokerInternal(t, true)
+
+	// Alphabet node is detected by the simple signature of its key.
+	ir := c.Committee.(neotest.MultiSigner).Single(0)
+	cIR := c.WithSigners(c.Committee, ir)
+
+	acc := c.NewAccount(t)
+	cAcc := c.WithSigners(acc)
+	cnt := dummyContainer(acc)
+	putArgs := []interface{}{cnt.value, cnt.sig, cnt.pub, cnt.token}
+
+	balanceMint(t, cBal, acc, containerFee*2, []byte{})
+
+	h := cAcc.Invoke(t, stackitem.Null{}, "put", putArgs...)
+	aer := cAcc.CheckHalt(t, h)
+	require.Equal(t, "containerPut", aer.Events[len(aer.Events)-1].Name)
+	c.InvokeFail(t, container.NotFoundError, "get", cnt.id[:])
+
+	// Fee is locked until the request is approved.
+	cBal.Invoke(t, stackitem.Make(containerFee), "balanceOf", acc.ScriptHash())
+	cBal.Invoke(t, stackitem.Make(containerFee), "lockedFee", cnt.id[:])
+	cAcc.InvokeFail(t, "fee is already locked", "put", putArgs...)
+
+	cIR.Invoke(t, stackitem.Null{}, "put", putArgs...)
+	c.Invoke(t, stackitem.Make(1), "count")
+	cBal.Invoke(t, stackitem.Make(containerFee), "balanceOf", acc.ScriptHash())
+	cBal.Invoke(t, stackitem.Make(0), "lockedFee", cnt.id[:])
+
+	t.Run("already exists", func(t *testing.T) {
+		cAcc.InvokeFail(t, container.AlreadyExistsError, "put", putArgs...)
+		cIR.InvokeFail(t, container.AlreadyExistsError, "put", putArgs...)
+	})
+
+	cIR.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+
+	t.Run("recently deleted", func(t *testing.T) {
+		cAcc.InvokeFail(t, container.AlreadyExistsError, "put", putArgs...)
+		cIR.InvokeFail(t, container.AlreadyExistsError, "put", putArgs...)
+	})
+
+	t.Run("fee is returned if not approved", func(t *testing.T) {
+		cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), container.FeeEscrowPeriodKey, int64(2))
+
+		cnt := dummyContainer(acc)
+		cAcc.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+		cBal.Invoke(t, stackitem.Make(0), "balanceOf", acc.ScriptHash())
+
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+		cBal.Invoke(t, stackitem.Make(0), "balanceOf", acc.ScriptHash())
+
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+		cBal.Invoke(t, stackitem.Make(containerFee), "balanceOf", acc.ScriptHash())
+		cBal.Invoke(t, stackitem.Make(0), "lockedFee", cnt.id[:])
+	})
+
+	t.Run("fee is returned if rejected", func(t *testing.T) {
+		cnt := dummyContainer(acc)
+		c.InvokeFail(t, common.ErrWitnessFailed, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+
+		cAcc.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+		cBal.Invoke(t, stackitem.Make(0), "balanceOf", acc.ScriptHash())
+		cBal.Invoke(t, stackitem.Make(containerFee), "lockedFee", cnt.id[:])
+
+		cAcc.InvokeFail(t, "method must be invoked by inner ring", "rejectPut", cnt.id[:])
+		cIR.Invoke(t, stackitem.Null{}, "rejectPut", cnt.id[:])
+		cBal.Invoke(t, stackitem.Make(containerFee), "balanceOf", acc.ScriptHash())
+		cBal.Invoke(t, stackitem.Make(0), "lockedFee", cnt.id[:])
+		c.InvokeFail(t, container.NotFoundError, "get", cnt.id[:])
+
+		cIR.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+		cBal.Invoke(t, stackitem.Make(0), "balanceOf", acc.ScriptHash())
+
+		balanceMint(t, cBal, acc, containerAliasFee*1, []byte{})
+		cAcc.Invoke(t, stackitem.Null{}, "setAlias", cnt.id[:], "mycnt", "", cnt.sig, cnt.token)
+		cBal.Invoke(t, stackitem.Make(0), "balanceOf", acc.ScriptHash())
+
+		cIR.Invoke(t, stackitem.Null{}, "rejectAlias", cnt.id[:])
+		cBal.Invoke(t, stackitem.Make(containerAliasFee), "balanceOf", acc.ScriptHash())
+		c.Invoke(t, stackitem.Make(""), "alias", cnt.id[:])
+	})
+}
+
 func addContainer(t *testing.T, c, cBal *neotest.ContractInvoker) (neotest.Signer, testContainer) {
 	acc := c.NewAccount(t)
 	cnt := dummyContainer(acc)
@@ -257,6 +732,178 @@ func TestContainerDelete(t *testing.T) {
 	c.InvokeFail(t, container.NotFoundError, "get", cnt.id[:])
 }
 
+func TestContainerDeletionInfo(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	const retention = 2
+	cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"),
+		container.TombstoneRetentionKey, int64(retention))
+
+	acc, cnt := addContainer(t, c, cBal)
+	c.InvokeFail(t, container.NotFoundError, "deletionInfo", cnt.id[:])
+
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+
+	owner, _ := base58.Decode(address.Uint160ToString(acc.ScriptHash()))
+	checkDeleted := func(t *testing.T, epoch int64, ids ...[]byte) {
+		s, err := c.TestInvoke(t, "deletedContainers", epoch)
+		require.NoError(t, err)
+
+		iter := s.Pop().Value().(*storage.Iterator)
+		actual := make([][]byte, 0, len(ids))
+		for _, item := range iteratorToArray(iter) {
+			id, err := item.TryBytes()
+			require.NoError(t, err)
+			actual = append(actual, id)
+		}
+		require.ElementsMatch(t, ids, actual)
+	}
+
+	s, err := c.TestInvoke(t, "deletionInfo", cnt.id[:])
+	require.NoError(t, err)
+
+	info := s.Pop().Array()
+	require.Equal(t, 2, len(info))
+	actualOwner, err := info[0].TryBytes()
+	require.NoError(t, err)
+	require.Equal(t, owner, actualOwner)
+	actualEpoch, err := info[1].TryInteger()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), actualEpoch.Int64())
+
+	checkDeleted(t, 0)
+	checkDeleted(t, 1, cnt.id[:])
+
+	for epoch := int64(2); epoch <= 1+retention; epoch++ {
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", epoch)
+		checkDeleted(t, 1, cnt.id[:])
+	}
+
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2+retention))
+	checkDeleted(t, 1)
+	c.InvokeFail(t, container.NotFoundError, "deletionInfo", cnt.id[:])
+
+	t.Run("retention is fixed at deletion time", func(t *testing.T) {
+		const deleteEpoch = 2 + retention
+
+		_, cnt := addContainer(t, c, cBal)
+		c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+		cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"),
+			container.TombstoneRetentionKey, int64(0))
+
+		for epoch := int64(deleteEpoch + 1); epoch <= deleteEpoch+retention; epoch++ {
+			cNm.Invoke(t, stackitem.Null{}, "newEpoch", epoch)
+			checkDeleted(t, deleteEpoch, cnt.id[:])
+		}
+
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(deleteEpoch+retention+1))
+		checkDeleted(t, deleteEpoch)
+		c.InvokeFail(t, container.NotFoundError, "deletionInfo", cnt.id[:])
+	})
+}
+
+func TestContainersByAttribute(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	checkByAttribute := func(t *testing.T, key, value string, expected ...testContainer) {
+		s, err := c.TestInvoke(t, "containersByAttribute", key, value)
+		require.NoError(t, err)
+
+		iter, ok := s.Pop().Value().(*storage.Iterator)
+		require.True(t, ok)
+
+		actual := [][]byte{}
+		for iter.Next() {
+			id, ok := iter.Value().Value().([]byte)
+			require.True(t, ok)
+			actual = append(actual, id)
+		}
+
+		ids := [][]byte{}
+		for i := range expected {
+			ids = append(ids, expected[i].id[:])
+		}
+		require.ElementsMatch(t, ids, actual)
+	}
+
+	acc := c.NewAccount(t)
+	cnt1 := containerWithAttributes(acc, "Name", "foo", "Zone", "container")
+	cnt2 := containerWithAttributes(acc, "Name", "bar", "Zone", "container")
+	long := string(randomBytes(200))
+	cnt3 := containerWithAttributes(acc, "Name", "foo", "Long", long)
+	cnt4 := containerWithAttributes(acc, "Name", "bar", "Other", "value")
+	dummy := dummyContainer(acc)
+
+	cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"),
+		container.IndexedAttributesKey, "Name,Zone,Long")
+
+	balanceMint(t, cBal, acc, containerFee*5, []byte{})
+	for _, cnt := range []testContainer{cnt1, cnt2, cnt3, cnt4, dummy} {
+		c.Invoke(t, stackitem.Null{}, "put", cnt.value, cnt.sig, cnt.pub, cnt.token)
+	}
+
+	checkByAttribute(t, "Name", "foo", cnt1, cnt3)
+	checkByAttribute(t, "Name", "bar", cnt2, cnt4)
+	checkByAttribute(t, "Name", "baz")
+	checkByAttribute(t, "Zone", "container", cnt1, cnt2)
+	checkByAttribute(t, "Long", long, cnt3)
+	checkByAttribute(t, "Other", "value")
+
+	c.Invoke(t, stackitem.Null{}, "delete", cnt1.id[:], cnt1.sig, cnt1.token)
+	checkByAttribute(t, "Name", "foo", cnt3)
+	checkByAttribute(t, "Zone", "container", cnt2)
+
+	c.Invoke(t, stackitem.Null{}, "delete", dummy.id[:], dummy.sig, dummy.token)
+	c.Invoke(t, stackitem.Null{}, "delete", cnt3.id[:], cnt3.sig, cnt3.token)
+	checkByAttribute(t, "Name", "foo")
+}
+
+func TestContainerPlacementPolicy(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	acc := c.NewAccount(t)
+	balanceMint(t, cBal, acc, containerFee*5, []byte{})
+
+	put := func(cnt testContainer) []interface{} {
+		return []interface{}{cnt.value, cnt.sig, cnt.pub, cnt.token}
+	}
+
+	cnt := containerWithPolicy(acc, placementPolicy(0, 1, 2))
+	c.InvokeFail(t, container.NotEnoughNodesError, "put", put(cnt)...)
+
+	for i := 0; i < 2; i++ {
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", dummyNodeInfo(c.NewAccount(t)).raw)
+	}
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+
+	c.Invoke(t, stackitem.Null{}, "put", put(cnt)...)
+
+	cnt = containerWithPolicy(acc, placementPolicy(0, 3))
+	c.InvokeFail(t, container.NotEnoughNodesError, "put", put(cnt)...)
+
+	t.Run("without placement policy", func(t *testing.T) {
+		cnt := containerWithAttributes(acc, "Name", "foo")
+		c.Invoke(t, stackitem.Null{}, "put", put(cnt)...)
+	})
+
+	t.Run("subnet", func(t *testing.T) {
+		ctrSubnet := neotest.CompileFile(t, c.CommitteeHash, subnetPath, path.Join(subnetPath, "config.yml"))
+		cSubnet := c.CommitteeInvoker(ctrSubnet.Hash)
+
+		cnt := containerWithPolicy(acc, placementPolicy(123, 1))
+		c.InvokeFail(t, subnet.ErrNotExist, "put", put(cnt)...)
+
+		owner := c.NewAccount(t)
+		pub, ok := vm.ParseSignatureContract(owner.Script())
+		require.True(t, ok)
+		cSubnet.WithSigners(c.Committee, owner).Invoke(t, stackitem.Null{},
+			"put", marshalSubnetID(123), pub, randomBytes(10))
+
+		c.Invoke(t, stackitem.Null{}, "put", put(cnt)...)
+	})
+}
+
 func TestContainerOwner(t *testing.T) {
 	c, cBal, _ := newContainerInvoker(t)
 
@@ -272,6 +919,96 @@ func TestContainerOwner(t *testing.T) {
 	c.Invoke(t, stackitem.NewBuffer(owner), "owner", cnt.id[:])
 }
 
+func TestContainerTransferOwnership(t *testing.T) {
+	c, cBal, _ := newContainerInvoker(t)
+	ctrFrostFSID := neotest.CompileFile(t, c.CommitteeHash, frostfsidPath, path.Join(frostfsidPath, "config.yml"))
+	cID := c.CommitteeInvoker(ctrFrostFSID.Hash)
+
+	acc, cnt := addContainer(t, c, cBal)
+	owner, _ := base58.Decode(address.Uint160ToString(acc.ScriptHash()))
+	ownerKey := acc.(neotest.SingleSigner).Account().PrivateKey().PublicKey().Bytes()
+
+	newAcc := c.NewAccount(t)
+	newOwner, _ := base58.Decode(address.Uint160ToString(newAcc.ScriptHash()))
+
+	checkOwner := func(t *testing.T, expected []byte) {
+		s, err := c.TestInvoke(t, "owner", cnt.id[:])
+		require.NoError(t, err)
+		actual, err := s.Pop().Item().TryBytes()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	boundKey := pk.PublicKey().Bytes()
+
+	sig := randomBytes(64)
+	t.Run("missing container", func(t *testing.T) {
+		id := cnt.id
+		id[0] ^= 0xFF
+		c.InvokeFail(t, container.NotFoundError, "transferOwnership",
+			id[:], newOwner, sig, ownerKey, cnt.token)
+	})
+	t.Run("invalid owner", func(t *testing.T) {
+		c.InvokeFail(t, "incorrect owner", "transferOwnership",
+			cnt.id[:], newOwner[1:], sig, ownerKey, cnt.token)
+	})
+	t.Run("same owner", func(t *testing.T) {
+		c.InvokeFail(t, "already owned", "transferOwnership",
+			cnt.id[:], owner, sig, ownerKey, cnt.token)
+	})
+	t.Run("unbound key", func(t *testing.T) {
+		c.InvokeFail(t, container.InvalidOwnerKeyError, "transferOwnership",
+			cnt.id[:], newOwner, sig, boundKey, cnt.token)
+	})
+	t.Run("no alphabet witness", func(t *testing.T) {
+		c.WithSigners(acc).InvokeFail(t, common.ErrAlphabetWitnessFailed, "transferOwnership",
+			cnt.id[:], newOwner, sig, ownerKey, cnt.token)
+	})
+
+	h := c.Invoke(t, stackitem.Null{}, "transferOwnership",
+		cnt.id[:], newOwner, sig, ownerKey, cnt.token)
+	aer := c.CheckHalt(t, h)
+	require.Equal(t, 1, len(aer.Events))
+	require.Equal(t, "TransferOwnershipSuccess", aer.Events[0].Name)
+	params := aer.Events[0].Item.Value().([]stackitem.Item)
+	require.Equal(t, 3, len(params))
+	for i, expected := range [][]byte{cnt.id[:], owner, newOwner} {
+		actual, err := params[i].TryBytes()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	checkOwner(t, newOwner)
+	c.Invoke(t, 0, "countByOwner", owner)
+	c.Invoke(t, 1, "countByOwner", newOwner)
+	checkContainerList(t, c, [][]byte{cnt.id[:]})
+
+	s, err := c.TestInvoke(t, "containersOf", newOwner)
+	require.NoError(t, err)
+	iter := s.Pop().Value().(*storage.Iterator)
+	require.True(t, iter.Next())
+	require.Equal(t, cnt.id[:], iter.Value().Value())
+	require.False(t, iter.Next())
+
+	t.Run("key bound in FrostFSID", func(t *testing.T) {
+		c.InvokeFail(t, container.InvalidOwnerKeyError, "transferOwnership",
+			cnt.id[:], owner, sig, ownerKey, cnt.token)
+
+		cID.Invoke(t, stackitem.Null{}, "addKey", newOwner, []interface{}{boundKey})
+		c.Invoke(t, stackitem.Null{}, "transferOwnership",
+			cnt.id[:], owner, sig, boundKey, cnt.token)
+		checkOwner(t, owner)
+		c.Invoke(t, 1, "countByOwner", owner)
+		c.Invoke(t, 0, "countByOwner", newOwner)
+	})
+
+	c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+	c.InvokeFail(t, container.NotFoundError, "owner", cnt.id[:])
+	c.Invoke(t, 0, "countByOwner", owner)
+}
+
 func TestContainerGet(t *testing.T) {
 	c, cBal, _ := newContainerInvoker(t)
 
@@ -338,6 +1075,84 @@ func TestContainerSetEACL(t *testing.T) {
 	c.Invoke(t, expected, "eACL", cnt.id[:])
 }
 
+func TestContainerEACLHistory(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	_, cnt := addContainer(t, c, cBal)
+
+	checkEACLAt := func(t *testing.T, epoch int64, expected []byte) {
+		s, err := c.TestInvoke(t, "eACLAt", cnt.id[:], epoch)
+		require.NoError(t, err)
+		actual, err := s.Pop().Array()[0].TryBytes()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	getHistory := func(t *testing.T) []stackitem.Item {
+		s, err := c.TestInvoke(t, "eACLHistory", cnt.id[:])
+		require.NoError(t, err)
+		return iteratorToArray(s.Pop().Value().(*storage.Iterator))
+	}
+
+	var n byte
+	setEACL := func(t *testing.T) eacl {
+		n++
+		e := dummyEACL(cnt.id)
+		e.value[len(e.value)-1] = n // make tables distinct
+		c.Invoke(t, stackitem.Null{}, "setEACL", e.value, e.sig, e.pub, e.token)
+		return e
+	}
+
+	checkEACLAt(t, 0, []byte{})
+	require.Empty(t, getHistory(t))
+
+	e1 := setEACL(t)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	e2 := setEACL(t)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(3))
+	e3 := setEACL(t)
+
+	checkEACLAt(t, 0, e1.value)
+	checkEACLAt(t, 1, e2.value)
+	checkEACLAt(t, 2, e2.value)
+	checkEACLAt(t, 3, e3.value)
+	checkEACLAt(t, 10, e3.value)
+
+	history := getHistory(t)
+	require.Equal(t, 3, len(history))
+	for i, expected := range []struct {
+		e     eacl
+		epoch int64
+	}{{e1, 0}, {e2, 1}, {e3, 3}} {
+		item := history[i].Value().([]stackitem.Item)
+		require.Equal(t, 3, len(item))
+
+		value, err := item[0].Value().([]stackitem.Item)[0].TryBytes()
+		require.NoError(t, err)
+		require.Equal(t, expected.e.value, value)
+		require.Equal(t, big.NewInt(expected.epoch), item[1].Value())
+		if i > 0 {
+			prev := history[i-1].Value().([]stackitem.Item)[2].Value().(*big.Int)
+			require.Equal(t, 1, item[2].Value().(*big.Int).Cmp(prev))
+		}
+	}
+
+	t.Run("history is bounded", func(t *testing.T) {
+		var last eacl
+		for i := 0; i < container.MaxEACLHistorySize; i++ {
+			last = setEACL(t)
+		}
+		require.Equal(t, container.MaxEACLHistorySize, len(getHistory(t)))
+		checkEACLAt(t, 0, []byte{})
+		checkEACLAt(t, 3, last.value)
+	})
+
+	c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+	c.InvokeFail(t, container.NotFoundError, "eACLAt", cnt.id[:], int64(3))
+	c.InvokeFail(t, container.NotFoundError, "eACL", cnt.id[:])
+	require.Empty(t, getHistory(t))
+}
+
 func TestContainerSizeEstimation(t *testing.T) {
 	c, cBal, cNm := newContainerInvoker(t)
 
@@ -407,6 +1222,307 @@ func TestContainerSizeEstimation(t *testing.T) {
 	}
 	checkEstimations(t, c, 2, cnt)
 	checkEstimations(t, c, epoch, cnt, estimation{nodes[1].pub, int64(999)})
+
+	t.Run("removed with the container", func(t *testing.T) {
+		c.Invoke(t, stackitem.Null{}, "delete", cnt.id[:], cnt.sig, cnt.token)
+		checkEstimations(t, c, epoch, cnt)
+	})
+}
+
+func TestContainerSizeSummary(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	_, cnt := addContainer(t, c, cBal)
+	nodes := make([]testNodeInfo, 5)
+	for i := range nodes {
+		nodes[i] = newStorageNode(t, c)
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[i].raw)
+	}
+
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	putSize := func(t *testing.T, node testNodeInfo, size int64) {
+		c.WithSigners(node.signer).Invoke(t, stackitem.Null{}, "putContainerSize",
+			int64(2), cnt.id[:], size, node.pub)
+	}
+	checkSummary := func(t *testing.T, epoch int64, nodes, median, min, max int64) {
+		expected := stackitem.NewStruct([]stackitem.Item{
+			stackitem.Make(nodes),
+			stackitem.Make(median),
+			stackitem.Make(min),
+			stackitem.Make(max),
+		})
+		c.Invoke(t, expected, "containerSizeSummary", epoch, cnt.id[:])
+	}
+
+	checkSummary(t, 2, 0, 0, 0, 0)
+
+	putSize(t, nodes[0], 100)
+	checkSummary(t, 2, 1, 100, 100, 100)
+
+	// Outliers can't be detected with 2 estimations.
+	putSize(t, nodes[1], 10000)
+	checkSummary(t, 2, 2, 5050, 100, 10000)
+
+	putSize(t, nodes[2], 110)
+	checkSummary(t, 2, 2, 105, 100, 110)
+
+	putSize(t, nodes[3], 95)
+	putSize(t, nodes[4], 1)
+	checkSummary(t, 2, 3, 100, 95, 110)
+
+	t.Run("estimation is updated", func(t *testing.T) {
+		putSize(t, nodes[4], 105)
+		checkSummary(t, 2, 4, 102, 95, 110)
+	})
+
+	for i := int64(1); i <= container.TotalCleanupDelta; i++ {
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", 2+i)
+	}
+	checkSummary(t, 2, 4, 102, 95, 110)
+
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", 3+container.TotalCleanupDelta)
+	checkSummary(t, 2, 0, 0, 0, 0)
+}
+
+func TestContainerSizeSummaryCleanup(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	_, cnt := addContainer(t, c, cBal)
+	node := newStorageNode(t, c)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", node.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	c.WithSigners(node.signer).Invoke(t, stackitem.Null{}, "putContainerSize",
+		int64(2), cnt.id[:], int64(100), node.pub)
+
+	// Size summaries must not share the prefix with other contract records.
+	id := c.Chain.GetContractState(c.Hash).ID
+	subnetKey := []byte("subnetScriptHash")
+	subnetHash := c.Chain.GetStorageItem(id, subnetKey)
+	require.NotNil(t, subnetHash)
+
+	for i := int64(1); i <= container.TotalCleanupDelta+1; i++ {
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", 2+i)
+	}
+	c.Invoke(t, stackitem.NewStruct([]stackitem.Item{
+		stackitem.Make(0), stackitem.Make(0), stackitem.Make(0), stackitem.Make(0),
+	}), "containerSizeSummary", int64(2), cnt.id[:])
+
+	require.Equal(t, subnetHash, c.Chain.GetStorageItem(id, subnetKey))
+}
+
+func TestContainerOwnerUsage(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	acc1, cnt1 := addContainer(t, c, cBal)
+	cnt2 := dummyContainer(acc1)
+	balanceMint(t, cBal, acc1, containerFee*1, []byte{})
+	c.Invoke(t, stackitem.Null{}, "put", cnt2.value, cnt2.sig, cnt2.pub, cnt2.token)
+	acc2, cnt3 := addContainer(t, c, cBal)
+
+	owner1, _ := base58.Decode(address.Uint160ToString(acc1.ScriptHash()))
+	owner2, _ := base58.Decode(address.Uint160ToString(acc2.ScriptHash()))
+
+	node := newStorageNode(t, c)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", node.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	for _, e := range []struct {
+		cnt  testContainer
+		size int64
+	}{{cnt1, 100}, {cnt2, 50}, {cnt3, 70}} {
+		c.WithSigners(node.signer).Invoke(t, stackitem.Null{}, "putContainerSize",
+			int64(2), e.cnt.id[:], e.size, node.pub)
+	}
+
+	c.Invoke(t, 0, "ownerUsage", owner1, int64(2))
+	c.Invoke(t, stackitem.Null{}, "stopContainerEstimation", int64(2))
+
+	checkUsage := func(t *testing.T) {
+		c.Invoke(t, 150, "ownerUsage", owner1, int64(2))
+		c.Invoke(t, 70, "ownerUsage", owner2, int64(2))
+		c.Invoke(t, 0, "ownerUsage", owner1, int64(1))
+
+		s, err := c.TestInvoke(t, "listOwnerUsage", int64(2))
+		require.NoError(t, err)
+		items := iteratorToArray(s.Pop().Value().(*storage.Iterator))
+		require.Equal(t, 2, len(items))
+
+		actual := make(map[string]int64)
+		for i := range items {
+			arr := items[i].Value().([]stackitem.Item)
+			owner, err := arr[0].TryBytes()
+			require.NoError(t, err)
+			size, err := arr[1].TryInteger()
+			require.NoError(t, err)
+			actual[string(owner)] = size.Int64()
+		}
+		require.Equal(t, map[string]int64{string(owner1): 150, string(owner2): 70}, actual)
+	}
+
+	checkUsage(t)
+
+	t.Run("repeated stop", func(t *testing.T) {
+		c.Invoke(t, stackitem.Null{}, "stopContainerEstimation", int64(2))
+		checkUsage(t)
+	})
+}
+
+func TestContainerQuota(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	acc, cnt := addContainer(t, c, cBal)
+	cnt2 := dummyContainer(acc)
+	balanceMint(t, cBal, acc, containerFee*1, []byte{})
+	c.Invoke(t, stackitem.Null{}, "put", cnt2.value, cnt2.sig, cnt2.pub, cnt2.token)
+
+	owner, _ := base58.Decode(address.Uint160ToString(acc.ScriptHash()))
+	ownerKey := acc.(neotest.SingleSigner).Account().PrivateKey().PublicKey().Bytes()
+	sig := randomBytes(64)
+
+	c.Invoke(t, 0, "quota", cnt.id[:])
+	cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), container.DefaultQuotaKey, int64(1000))
+	c.Invoke(t, 1000, "quota", cnt.id[:])
+	c.Invoke(t, 0, "quota", owner)
+
+	t.Run("invalid", func(t *testing.T) {
+		c.InvokeFail(t, "invalid quota limit", "setQuota", cnt.id[:], int64(-1), sig, ownerKey, cnt.token)
+		c.InvokeFail(t, "incorrect container or owner ID", "setQuota", owner[1:], int64(1), sig, ownerKey, cnt.token)
+
+		id := cnt.id
+		id[0] ^= 0xFF
+		c.InvokeFail(t, container.NotFoundError, "setQuota", id[:], int64(1), sig, ownerKey, cnt.token)
+
+		pk, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		c.InvokeFail(t, container.InvalidOwnerKeyError, "setQuota",
+			cnt.id[:], int64(1), sig, pk.PublicKey().Bytes(), cnt.token)
+
+		c.WithSigners(acc).InvokeFail(t, common.ErrAlphabetWitnessFailed, "setQuota",
+			cnt.id[:], int64(1), sig, ownerKey, cnt.token)
+	})
+
+	h := c.Invoke(t, stackitem.Null{}, "setQuota", cnt.id[:], int64(100), sig, ownerKey, cnt.token)
+	aer := c.CheckHalt(t, h)
+	require.Equal(t, 1, len(aer.Events))
+	require.Equal(t, "SetQuotaSuccess", aer.Events[0].Name)
+	c.Invoke(t, 100, "quota", cnt.id[:])
+
+	c.Invoke(t, stackitem.Null{}, "setQuota", owner, int64(150), sig, ownerKey, cnt.token)
+	c.Invoke(t, 150, "quota", owner)
+
+	nodes := []testNodeInfo{newStorageNode(t, c), newStorageNode(t, c)}
+	for i := range nodes {
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[i].raw)
+	}
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	putSize := func(t *testing.T, node testNodeInfo, cnt testContainer, size int64) []state.NotificationEvent {
+		h := c.WithSigners(node.signer).Invoke(t, stackitem.Null{}, "putContainerSize",
+			int64(2), cnt.id[:], size, node.pub)
+		return c.CheckHalt(t, h).Events
+	}
+	checkExceeded := func(t *testing.T, events []state.NotificationEvent, name string, id []byte, used, limit int64) {
+		require.Equal(t, 1, len(events))
+		require.Equal(t, name, events[0].Name)
+
+		arr := events[0].Item.Value().([]stackitem.Item)
+		require.Equal(t, 4, len(arr))
+		actualID, err := arr[0].TryBytes()
+		require.NoError(t, err)
+		require.Equal(t, id, actualID)
+		for i, expected := range []int64{2, used, limit} {
+			actual, err := arr[i+1].TryInteger()
+			require.NoError(t, err)
+			require.Equal(t, expected, actual.Int64())
+		}
+	}
+
+	require.Empty(t, putSize(t, nodes[0], cnt, 90))
+	checkExceeded(t, putSize(t, nodes[1], cnt, 120), "QuotaExceeded", cnt.id[:], 105, 100)
+	require.Empty(t, putSize(t, nodes[0], cnt, 130)) // already exceeded
+	require.Empty(t, putSize(t, nodes[0], cnt2, 50))
+
+	h = c.Invoke(t, stackitem.Null{}, "stopContainerEstimation", int64(2))
+	events := c.CheckHalt(t, h).Events
+	checkExceeded(t, events[:len(events)-1], "OwnerQuotaExceeded", owner, 175, 150)
+	require.Equal(t, "StopEstimation", events[len(events)-1].Name)
+
+	c.Invoke(t, stackitem.Null{}, "setQuota", cnt.id[:], int64(0), sig, ownerKey, cnt.token)
+	c.Invoke(t, 1000, "quota", cnt.id[:])
+
+	t.Run("removed with the container", func(t *testing.T) {
+		c.Invoke(t, stackitem.Null{}, "setQuota", cnt2.id[:], int64(10), sig, ownerKey, cnt2.token)
+		c.Invoke(t, 10, "quota", cnt2.id[:])
+		c.Invoke(t, stackitem.Null{}, "delete", cnt2.id[:], cnt2.sig, cnt2.token)
+		c.Invoke(t, 1000, "quota", cnt2.id[:])
+	})
+}
+
+func TestContainerPutContainerSizes(t *testing.T) {
+	c, cBal, cNm := newContainerInvoker(t)
+
+	const count = 10
+	cnts := make([]testContainer, count)
+	for i := range cnts {
+		_, cnts[i] = addContainer(t, c, cBal)
+	}
+
+	nodes := []testNodeInfo{newStorageNode(t, c), newStorageNode(t, c)}
+	for i := range nodes {
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[i].raw)
+	}
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	sizes := make([]interface{}, count)
+	for i := range cnts {
+		sizes[i] = []interface{}{cnts[i].id[:], int64(100 + i)}
+	}
+
+	cBatch := c.WithSigners(nodes[1].signer)
+	t.Run("missing container", func(t *testing.T) {
+		id := cnts[0].id
+		id[0] ^= 0xFF
+		cBatch.InvokeFail(t, container.NotFoundError, "putContainerSizes", int64(2),
+			[]interface{}{[]interface{}{id[:], int64(1)}}, nodes[1].pub)
+	})
+	t.Run("not a storage node", func(t *testing.T) {
+		acc := c.NewAccount(t)
+		pub := acc.(neotest.SingleSigner).Account().PrivateKey().PublicKey().Bytes()
+		c.WithSigners(acc).InvokeFail(t, "method must be invoked by storage node from network map",
+			"putContainerSizes", int64(2), sizes, pub)
+	})
+	t.Run("must be witnessed by key in the argument", func(t *testing.T) {
+		c.WithSigners(nodes[0].signer).InvokeFail(t, common.ErrWitnessFailed,
+			"putContainerSizes", int64(2), sizes, nodes[1].pub)
+	})
+
+	var singleGas int64
+	for i := range cnts {
+		h := c.WithSigners(nodes[0].signer).Invoke(t, stackitem.Null{}, "putContainerSize",
+			int64(2), cnts[i].id[:], int64(100+i), nodes[0].pub)
+		singleGas += c.CheckHalt(t, h).GasConsumed
+	}
+
+	h := cBatch.Invoke(t, stackitem.Null{}, "putContainerSizes", int64(2), sizes, nodes[1].pub)
+	batchGas := c.CheckHalt(t, h).GasConsumed
+
+	t.Logf("GAS consumed for %d containers: putContainerSize %d, putContainerSizes %d",
+		count, singleGas, batchGas)
+	require.Less(t, batchGas, singleGas)
+
+	for i := range cnts {
+		requireEstimationsMatch(t, []estimation{
+			{nodes[0].pub, int64(100 + i)},
+			{nodes[1].pub, int64(100 + i)},
+		}, getListEstimations(t, c, 2, cnts[i]))
+	}
 }
 
 type estimation struct {
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// testInvoker implements Invoker interface of RPC wrappers on top of
+// neotest executor, so that wrappers can be checked without RPC server.
+// Iterators are kept in memory and can be traversed like in RPC sessions.
+type testInvoker struct {
+	t         testing.TB
+	e         *neotest.Executor
+	session   uuid.UUID
+	iterators map[uuid.UUID]*storage.Iterator
+}
+
+func newTestInvoker(t testing.TB, e *neotest.Executor) *testInvoker {
+	return &testInvoker{
+		t:         t,
+		e:         e,
+		session:   uuid.New(),
+		iterators: make(map[uuid.UUID]*storage.Iterator),
+	}
+}
+
+func (i *testInvoker) Call(contract util.Uint160, operation string, params ...interface{}) (*result.Invoke, error) {
+	s, err := i.e.CommitteeInvoker(contract).TestInvoke(i.t, operation, params...)
+	if err != nil {
+		return &result.Invoke{State: vmstate.Fault.String(), FaultException: err.Error()}, nil
+	}
+
+	items := s.ToArray()
+	for k := range items {
+		iter, ok := items[k].Value().(*storage.Iterator)
+		if !ok {
+			continue
+		}
+
+		id := uuid.New()
+		i.iterators[id] = iter
+		items[k] = stackitem.NewInterop(result.Iterator{ID: &id})
+	}
+
+	return &result.Invoke{
+		State:   vmstate.Halt.String(),
+		Stack:   items,
+		Session: i.session,
+	}, nil
+}
+
+func (i *testInvoker) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...interface{}) (*result.Invoke, error) {
+	s, err := i.e.CommitteeInvoker(contract).TestInvoke(i.t, method, params...)
+	if err != nil {
+		return &result.Invoke{State: vmstate.Fault.String(), FaultException: err.Error()}, nil
+	}
+
+	iter, ok := s.Pop().Value().(*storage.Iterator)
+	if !ok {
+		return nil, errors.New("not an iterator")
+	}
+
+	var items []stackitem.Item
+	for len(items) < maxItems && iter.Next() {
+		items = append(items, iter.Value())
+	}
+
+	return &result.Invoke{
+		State: vmstate.Halt.String(),
+		Stack: []stackitem.Item{stackitem.NewArray(items)},
+	}, nil
+}
+
+func (i *testInvoker) TerminateSession(sessionID uuid.UUID) error {
+	if sessionID != i.session {
+		return errors.New("unknown session")
+	}
+	i.iterators = make(map[uuid.UUID]*storage.Iterator)
+	return nil
+}
+
+func (i *testInvoker) TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error) {
+	if sessionID != i.session || iterator.ID == nil {
+		return nil, errors.New("unknown session")
+	}
+
+	iter, ok := i.iterators[*iterator.ID]
+	if !ok {
+		return nil, errors.New("unknown iterator")
+	}
+
+	var items []stackitem.Item
+	for len(items) < num && iter.Next() {
+		items = append(items, iter.Value())
+	}
+	return items, nil
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/TrueCloudLab/frostfs-contract/netmap"
+	nmclient "github.com/TrueCloudLab/frostfs-contract/rpcclient/netmap"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetmapRPCClient(t *testing.T) {
+	cNm := newNetmapInvoker(t,
+		netmap.UnknownConfigAllowedKey, true,
+		"IntKey", int64(123),
+		"BoolKey", true,
+		"BytesKey", []byte{1, 2, 3})
+	reader := nmclient.NewReader(newTestInvoker(t, cNm.Executor), cNm.Hash)
+
+	t.Run("config", func(t *testing.T) {
+		i, err := reader.ConfigInt([]byte("IntKey"))
+		require.NoError(t, err)
+		require.Equal(t, int64(123), i.Int64())
+
+		b, err := reader.ConfigBool([]byte("BoolKey"))
+		require.NoError(t, err)
+		require.True(t, b)
+
+		bs, err := reader.ConfigBytes([]byte("BytesKey"))
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, bs)
+
+		_, err = reader.ConfigInt([]byte("MissingKey"))
+		require.ErrorIs(t, err, nmclient.ErrConfigNotFound)
+
+		records, err := reader.ListConfig()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+
+		_, err = reader.ConfigTyped([]byte("IntKey"))
+		require.Error(t, err)
+
+		typed, err := reader.ConfigTyped([]byte(netmap.UnknownConfigAllowedKey))
+		require.NoError(t, err)
+		require.Equal(t, stackitem.NewBool(true), typed)
+	})
+
+	nodes := []testNodeInfo{newStorageNode(t, cNm), newStorageNode(t, cNm)}
+	for i := range nodes {
+		h := cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[i].raw)
+
+		events, err := nmclient.AddPeerSuccessEventsFromApplicationLog(applicationLog(t, cNm, h))
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, nodes[i].pub, events[0].PublicKey.Bytes())
+	}
+
+	candidates, err := reader.NetmapCandidates()
+	require.NoError(t, err)
+	require.Len(t, candidates, len(nodes))
+
+	h := cNm.Invoke(t, stackitem.Null{}, "updateStateIR", int64(netmap.NodeStateMaintenance), nodes[1].pub)
+	updEvents, err := nmclient.UpdateStateSuccessEventsFromApplicationLog(applicationLog(t, cNm, h))
+	require.NoError(t, err)
+	require.Len(t, updEvents, 1)
+	require.Equal(t, nodes[1].pub, updEvents[0].PublicKey.Bytes())
+	require.Equal(t, nmclient.NodeStateMaintenance, updEvents[0].State)
+
+	h = cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	epochEvents, err := nmclient.NewEpochEventsFromApplicationLog(applicationLog(t, cNm, h))
+	require.NoError(t, err)
+	require.Len(t, epochEvents, 1)
+	require.Equal(t, int64(1), epochEvents[0].Epoch.Int64())
+
+	epoch, err := reader.Epoch()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), epoch.Int64())
+
+	checkNodes := func(t *testing.T, actual []*nmclient.Node) {
+		require.Len(t, actual, len(nodes))
+		for i := range nodes {
+			expected := &nmclient.Node{BLOB: nodes[i].raw, State: nmclient.NodeStateOnline}
+			if i == 1 {
+				expected.State = nmclient.NodeStateMaintenance
+			}
+			require.Contains(t, actual, expected)
+		}
+	}
+
+	nm, err := reader.Netmap()
+	require.NoError(t, err)
+	checkNodes(t, nm)
+
+	nm, err = reader.Snapshot(big.NewInt(0))
+	require.NoError(t, err)
+	checkNodes(t, nm)
+
+	nm, err = reader.SnapshotByEpoch(big.NewInt(1))
+	require.NoError(t, err)
+	checkNodes(t, nm)
+
+	nm, err = reader.Snapshot(big.NewInt(1))
+	require.NoError(t, err)
+	require.Empty(t, nm)
+
+	_, err = reader.Snapshot(big.NewInt(netmap.DefaultSnapshotCount))
+	require.Error(t, err)
+
+	cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), netmap.MaxNodeTTLKey, int64(1))
+	h = cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+	evictEvents, err := nmclient.PeerEvictedEventsFromApplicationLog(applicationLog(t, cNm, h))
+	require.NoError(t, err)
+	require.Len(t, evictEvents, 1)
+	require.Equal(t, nodes[0].pub, evictEvents[0].PublicKey.Bytes())
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"encoding/json"
 	"math/big"
 	"math/rand"
 	"path"
@@ -10,6 +11,7 @@ import (
 	"github.com/TrueCloudLab/frostfs-contract/common"
 	"github.com/TrueCloudLab/frostfs-contract/container"
 	"github.com/TrueCloudLab/frostfs-contract/netmap"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -51,11 +53,113 @@ func newNetmapInvoker(t *testing.T, config ...interface{}) *neotest.ContractInvo
 	return e.CommitteeInvoker(ctrNetmap.Hash)
 }
 
+// updateNetmapContract updates the deployed netmap contract with the same code.
+// Version of the previous release is passed as the last deploy argument, so
+// the version appended by Update is ignored and update migrations are run.
+func updateNetmapContract(t *testing.T, c *neotest.ContractInvoker, config ...interface{}) {
+	c.Invoke(t, stackitem.Null{}, "update", netmapUpdateArgs(t, c, config...)...)
+}
+
+// netmapUpdateArgs returns the arguments of the netmap contract update with
+// the same code, see updateNetmapContract.
+func netmapUpdateArgs(t *testing.T, c *neotest.ContractInvoker, config ...interface{}) []interface{} {
+	ctr := neotest.CompileFile(t, c.CommitteeHash, netmapPath, path.Join(netmapPath, "config.yml"))
+
+	rawNef, err := ctr.NEF.Bytes()
+	require.NoError(t, err)
+	rawManifest, err := json.Marshal(ctr.Manifest)
+	require.NoError(t, err)
+
+	if config == nil {
+		config = []interface{}{}
+	}
+	data := []interface{}{false, util.Uint160{}, util.Uint160{}, []interface{}{}, config, common.PrevVersion}
+	return []interface{}{rawNef, rawManifest, data}
+}
+
 func TestDeploySetConfig(t *testing.T) {
-	c := newNetmapInvoker(t, "SomeKey", "TheValue", container.AliasFeeKey, int64(123))
+	c := newNetmapInvoker(t, netmap.UnknownConfigAllowedKey, true,
+		"SomeKey", "TheValue", container.AliasFeeKey, int64(123))
 	c.Invoke(t, "TheValue", "config", "SomeKey")
 	c.Invoke(t, stackitem.NewByteArray(bigint.ToBytes(big.NewInt(123))),
 		"config", container.AliasFeeKey)
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		e := newExecutor(t)
+		_, pubs, ok := vm.ParseMultiSigContract(e.Committee.Script())
+		require.True(t, ok)
+
+		ctr := neotest.CompileFile(t, e.CommitteeHash, netmapPath, path.Join(netmapPath, "config.yml"))
+		args := []interface{}{false, util.Uint160{1}, util.Uint160{2}, []interface{}{pubs[0]},
+			[]interface{}{container.AliasFeeKey, int64(123), "SomeKey", "TheValue"}}
+		e.DeployContractCheckFAULT(t, ctr, args, "unknown configuration key: SomeKey")
+
+		args[4] = []interface{}{container.AliasFeeKey, int64(-1)}
+		e.DeployContractCheckFAULT(t, ctr, args, "configuration value is out of range: "+container.AliasFeeKey)
+	})
+}
+
+func TestConfigUpdate(t *testing.T) {
+	c := newNetmapInvoker(t, netmap.UnknownConfigAllowedKey, true, "SomeKey", "TheValue")
+
+	// Stored values are validated against the schema on update.
+	c.InvokeFail(t, "unknown configuration key: SomeKey", "update",
+		netmapUpdateArgs(t, c, netmap.UnknownConfigAllowedKey, false)...)
+
+	updateNetmapContract(t, c)
+	c.Invoke(t, "TheValue", "config", "SomeKey")
+}
+
+func TestConfigSchema(t *testing.T) {
+	cNm := newNetmapInvoker(t)
+
+	setConfig := func(key string, value interface{}) {
+		cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), key, value)
+	}
+	setConfigFail := func(errMsg, key string, value interface{}) {
+		cNm.InvokeFail(t, errMsg, "setConfig", []byte("id"), key, value)
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		setConfigFail("unknown configuration key: SomeKey", "SomeKey", "TheValue")
+		cNm.InvokeFail(t, "unknown configuration key: SomeKey", "configTyped", "SomeKey")
+	})
+	t.Run("integer", func(t *testing.T) {
+		setConfigFail("configuration value is out of range", container.FeeAlphabetShareKey, int64(101))
+		setConfigFail("configuration value is out of range", container.FeeAlphabetShareKey, int64(-1))
+		setConfigFail("configuration value is out of range", netmap.MaxNodeTTLKey, int64(-1))
+		setConfigFail("configuration value is out of range", container.FeeEscrowPeriodKey, int64(0))
+
+		cNm.Invoke(t, stackitem.Null{}, "configTyped", container.FeeAlphabetShareKey)
+		setConfig(container.FeeAlphabetShareKey, int64(100))
+		cNm.Invoke(t, 100, "configTyped", container.FeeAlphabetShareKey)
+		setConfig(container.FeeAlphabetShareKey, int64(0))
+		cNm.Invoke(t, 0, "configTyped", container.FeeAlphabetShareKey)
+	})
+	t.Run("boolean", func(t *testing.T) {
+		setConfigFail("invalid boolean configuration value", netmap.MaintenanceExpiryOnlineKey, int64(2))
+
+		setConfig(netmap.MaintenanceExpiryOnlineKey, true)
+		cNm.Invoke(t, true, "configTyped", netmap.MaintenanceExpiryOnlineKey)
+		setConfig(netmap.MaintenanceExpiryOnlineKey, false)
+		cNm.Invoke(t, false, "configTyped", netmap.MaintenanceExpiryOnlineKey)
+	})
+	t.Run("script hash", func(t *testing.T) {
+		setConfigFail("invalid script hash configuration value", container.FeeTreasuryKey, []byte{1, 2, 3})
+
+		h := util.Uint160{1, 2, 3}
+		setConfig(container.FeeTreasuryKey, h)
+		cNm.Invoke(t, h.BytesBE(), "configTyped", container.FeeTreasuryKey)
+	})
+	t.Run("unknown keys are allowed", func(t *testing.T) {
+		setConfig(netmap.UnknownConfigAllowedKey, true)
+		setConfig("SomeKey", "TheValue")
+		cNm.Invoke(t, "TheValue", "config", "SomeKey")
+		cNm.InvokeFail(t, "unknown configuration key: SomeKey", "configTyped", "SomeKey")
+
+		setConfig(netmap.UnknownConfigAllowedKey, false)
+		setConfigFail("unknown configuration key: OtherKey", "OtherKey", "TheValue")
+	})
 }
 
 type testNodeInfo struct {
@@ -168,6 +272,60 @@ func TestNewEpoch(t *testing.T) {
 	}
 }
 
+func TestEpochHistory(t *testing.T) {
+	cNm := newNetmapInvoker(t)
+
+	const epochCount = 3
+
+	blocks := make([]int64, epochCount+1)
+	times := make([]int64, epochCount+1)
+	for i := 1; i <= epochCount; i++ {
+		cNm.Invoke(t, stackitem.Null{}, "newEpoch", i)
+		times[i] = int64(cNm.TopBlock(t).Timestamp)
+
+		s, err := cNm.TestInvoke(t, "lastEpochBlock")
+		require.NoError(t, err)
+		blocks[i] = s.Pop().BigInt().Int64()
+
+		for j := 0; j < i; j++ {
+			cNm.AddNewBlock(t)
+		}
+	}
+
+	for i := 1; i <= epochCount; i++ {
+		cNm.Invoke(t, blocks[i], "epochBlock", i)
+		cNm.Invoke(t, times[i], "epochTime", i)
+		cNm.Invoke(t, i, "epochByBlock", blocks[i])
+		cNm.Invoke(t, i, "epochByBlock", blocks[i]+int64(i))
+	}
+	cNm.Invoke(t, epochCount, "epochByBlock", blocks[epochCount]+100)
+
+	cNm.InvokeFail(t, "block is out of epoch history", "epochByBlock", blocks[1]-1)
+	cNm.InvokeFail(t, "epoch is out of history", "epochBlock", 0)
+	cNm.InvokeFail(t, "epoch is out of history", "epochTime", epochCount+1)
+	cNm.InvokeFail(t, "epoch is out of history", "epochBlock", -1)
+
+	t.Run("old epochs are overwritten", func(t *testing.T) {
+		for i := epochCount + 1; i <= netmap.EpochHistorySize+1; i++ {
+			cNm.Invoke(t, stackitem.Null{}, "newEpoch", i)
+		}
+
+		cNm.InvokeFail(t, "epoch is out of history", "epochBlock", 1)
+		cNm.Invoke(t, blocks[2], "epochBlock", 2)
+		cNm.InvokeFail(t, "block is out of epoch history", "epochByBlock", blocks[1])
+	})
+
+	t.Run("update", func(t *testing.T) {
+		cNm := newNetmapInvoker(t)
+		cNm.InvokeFail(t, "epoch is out of history", "epochBlock", 0)
+
+		updateNetmapContract(t, cNm)
+		cNm.Invoke(t, 0, "epochBlock", 0)
+		cNm.Invoke(t, 0, "epochTime", 0)
+		cNm.Invoke(t, 0, "epochByBlock", cNm.Chain.BlockHeight())
+	})
+}
+
 func TestUpdateSnapshotCount(t *testing.T) {
 	rand.Seed(42)
 
@@ -284,11 +442,24 @@ func checkSnapshotAt(t *testing.T, epoch int, cNm *neotest.ContractInvoker, node
 	require.NoError(t, err)
 	require.Equal(t, 1, s.Len())
 	checkSnapshot(t, s, nodes)
+
+	s, err = cNm.TestInvoke(t, "snapshotIterator", int64(epoch))
+	require.NoError(t, err)
+	require.Equal(t, 1, s.Len())
+	checkNodes(t, iteratorToArray(s.Pop().Value().(*storage.Iterator)), nodes)
+
+	for i := range nodes {
+		cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", int64(epoch), nodes[i].pub)
+	}
 }
 
 func checkSnapshot(t *testing.T, s *vm.Stack, nodes []testNodeInfo) {
 	arr, ok := s.Pop().Value().([]stackitem.Item)
 	require.True(t, ok, "expected array")
+	checkNodes(t, arr, nodes)
+}
+
+func checkNodes(t *testing.T, arr []stackitem.Item, nodes []testNodeInfo) {
 	require.Equal(t, len(nodes), len(arr), "expected %d nodes", len(nodes))
 
 	actual := make([]netmap.Node, len(nodes))
@@ -312,6 +483,92 @@ func checkSnapshot(t *testing.T, s *vm.Stack, nodes []testNodeInfo) {
 	require.ElementsMatch(t, expected, actual, "snapshot is different")
 }
 
+func TestIsInSnapshot(t *testing.T) {
+	cNm := newNetmapInvoker(t)
+
+	first := newStorageNode(t, cNm)
+	second := newStorageNode(t, cNm)
+	outsider := newStorageNode(t, cNm)
+
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", first.raw)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", second.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", 1)
+
+	cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", 0, first.pub)
+	cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", 0, second.pub)
+	cNm.Invoke(t, stackitem.NewBool(false), "isInSnapshot", 0, outsider.pub)
+	cNm.Invoke(t, stackitem.NewBool(false), "isInSnapshot", 1, first.pub)
+
+	cNm.Invoke(t, stackitem.Null{}, "updateStateIR", int64(netmap.NodeStateOffline), second.pub)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", outsider.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", 2)
+
+	cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", 0, first.pub)
+	cNm.Invoke(t, stackitem.NewBool(false), "isInSnapshot", 0, second.pub)
+	cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", 0, outsider.pub)
+	cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", 1, first.pub)
+	cNm.Invoke(t, stackitem.NewBool(true), "isInSnapshot", 1, second.pub)
+	cNm.Invoke(t, stackitem.NewBool(false), "isInSnapshot", 1, outsider.pub)
+
+	t.Run("stale entries are removed", func(t *testing.T) {
+		for i := 2; i < netmap.DefaultSnapshotCount+1; i++ {
+			cNm.Invoke(t, stackitem.Null{}, "newEpoch", i+1)
+		}
+
+		// Snapshot with `second` node was overwritten.
+		for i := 0; i < netmap.DefaultSnapshotCount; i++ {
+			cNm.Invoke(t, stackitem.NewBool(false), "isInSnapshot", int64(i), second.pub)
+		}
+	})
+
+	cNm.InvokeFail(t, "incorrect diff", "isInSnapshot", -1, first.pub)
+	cNm.InvokeFail(t, "incorrect diff", "isInSnapshot", netmap.DefaultSnapshotCount, first.pub)
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	cNm := newNetmapInvoker(t)
+
+	kept := newStorageNode(t, cNm)
+	changed := newStorageNode(t, cNm)
+	removed := newStorageNode(t, cNm)
+	added := newStorageNode(t, cNm)
+
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", kept.raw)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", changed.raw)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", removed.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", 1)
+
+	cNm.Invoke(t, stackitem.Null{}, "updateStateIR", int64(netmap.NodeStateOffline), removed.pub)
+	cNm.Invoke(t, stackitem.Null{}, "updateStateIR", int64(netmap.NodeStateMaintenance), changed.pub)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", added.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", 2)
+
+	checkDiff := func(t *testing.T, epochA, epochB int64, expected ...[]testNodeInfo) {
+		s, err := cNm.TestInvoke(t, "snapshotDiff", epochA, epochB)
+		require.NoError(t, err)
+		require.Equal(t, 1, s.Len())
+
+		fields, ok := s.Pop().Value().([]stackitem.Item)
+		require.True(t, ok, "expected struct")
+		require.Equal(t, 3, len(fields))
+		for i := range fields {
+			arr, ok := fields[i].Value().([]stackitem.Item)
+			require.True(t, ok, "expected array")
+			checkNodes(t, arr, expected[i])
+		}
+	}
+
+	changed.state = netmap.NodeStateMaintenance
+	checkDiff(t, 1, 2, []testNodeInfo{added}, []testNodeInfo{removed}, []testNodeInfo{changed})
+	checkDiff(t, 2, 2, nil, nil, nil)
+
+	changed.state = netmap.NodeStateOnline
+	checkDiff(t, 2, 1, []testNodeInfo{removed}, []testNodeInfo{added}, []testNodeInfo{changed})
+
+	cNm.InvokeFail(t, "incorrect diff", "snapshotDiff", 1, 3)
+	cNm.InvokeFail(t, "incorrect diff", "snapshotDiff", 2-netmap.DefaultSnapshotCount, 2)
+}
+
 func TestUpdateStateIR(t *testing.T) {
 	cNm := newNetmapInvoker(t)
 
@@ -402,6 +659,163 @@ func TestUpdateState(t *testing.T) {
 	checkNetmapCandidates(t, cNm, 0)
 }
 
+func TestUpdateStateWithDeadline(t *testing.T) {
+	cNm := newNetmapInvoker(t)
+
+	nodes := []testNodeInfo{newStorageNode(t, cNm), newStorageNode(t, cNm)}
+	for i := range nodes {
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[i].raw)
+	}
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", 1)
+
+	t.Run("invalid state", func(t *testing.T) {
+		cNm.InvokeFail(t, "deadline is supported for maintenance state only",
+			"updateStateWithDeadlineIR", int64(netmap.NodeStateOnline), nodes[0].pub, 3)
+	})
+	t.Run("invalid deadline", func(t *testing.T) {
+		cNm.InvokeFail(t, "invalid maintenance deadline",
+			"updateStateWithDeadlineIR", int64(netmap.NodeStateMaintenance), nodes[0].pub, 1)
+	})
+	t.Run("must be signed by the alphabet", func(t *testing.T) {
+		cAcc := cNm.WithSigners(nodes[0].signer)
+		cAcc.InvokeFail(t, common.ErrAlphabetWitnessFailed,
+			"updateStateWithDeadlineIR", int64(netmap.NodeStateMaintenance), nodes[0].pub, 3)
+	})
+
+	checkExpired := func(t *testing.T, epoch int64, pub []byte, state netmap.NodeState) {
+		h := cNm.Invoke(t, stackitem.Null{}, "newEpoch", epoch)
+		aer := cNm.CheckHalt(t, h)
+
+		var found bool
+		for _, ev := range aer.Events {
+			if ev.Name != "UpdateStateSuccess" {
+				continue
+			}
+			require.False(t, found, "single transition is expected")
+			found = true
+
+			params := ev.Item.Value().([]stackitem.Item)
+			actualPub, err := params[0].TryBytes()
+			require.NoError(t, err)
+			require.Equal(t, pub, actualPub)
+			actualState, err := params[1].TryInteger()
+			require.NoError(t, err)
+			require.Equal(t, int64(state), actualState.Int64())
+		}
+		require.Equal(t, pub != nil, found)
+	}
+
+	cNm.Invoke(t, stackitem.Null{}, "updateStateWithDeadlineIR",
+		int64(netmap.NodeStateMaintenance), nodes[0].pub, 3)
+
+	nodes[0].state = netmap.NodeStateMaintenance
+	checkExpired(t, 2, nil, 0)
+	checkSnapshotAt(t, 0, cNm, nodes)
+
+	checkExpired(t, 3, nodes[0].pub, netmap.NodeStateOffline)
+	checkSnapshotAt(t, 0, cNm, nodes[1:])
+	checkNetmapCandidates(t, cNm, 1)
+
+	t.Run("move online if configured", func(t *testing.T) {
+		cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), netmap.MaintenanceExpiryOnlineKey, true)
+		cNm.Invoke(t, stackitem.Null{}, "updateStateWithDeadlineIR",
+			int64(netmap.NodeStateMaintenance), nodes[1].pub, 5)
+
+		checkExpired(t, 4, nil, 0)
+		checkExpired(t, 6, nodes[1].pub, netmap.NodeStateOnline)
+		checkSnapshotAt(t, 0, cNm, nodes[1:])
+	})
+	t.Run("deadline is reset by bootstrap", func(t *testing.T) {
+		cNm.Invoke(t, stackitem.Null{}, "updateStateWithDeadlineIR",
+			int64(netmap.NodeStateMaintenance), nodes[1].pub, 7)
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[1].raw)
+
+		checkExpired(t, 7, nil, 0)
+		checkSnapshotAt(t, 0, cNm, nodes[1:])
+	})
+	t.Run("deadline is reset by state update", func(t *testing.T) {
+		cNm.Invoke(t, stackitem.Null{}, "updateStateWithDeadlineIR",
+			int64(netmap.NodeStateMaintenance), nodes[1].pub, 8)
+		cNm.Invoke(t, stackitem.Null{}, "updateStateIR",
+			int64(netmap.NodeStateMaintenance), nodes[1].pub)
+
+		nodes[1].state = netmap.NodeStateMaintenance
+		checkExpired(t, 8, nil, 0)
+		checkSnapshotAt(t, 0, cNm, nodes[1:])
+	})
+}
+
+func TestEvictCandidates(t *testing.T) {
+	cNm := newNetmapInvoker(t, netmap.MaxNodeTTLKey, int64(2))
+
+	newEpoch := func(t *testing.T, epoch int64, evicted ...testNodeInfo) {
+		h := cNm.Invoke(t, stackitem.Null{}, "newEpoch", epoch)
+		aer := cNm.CheckHalt(t, h)
+
+		var actual [][]byte
+		for _, ev := range aer.Events {
+			if ev.Name == "PeerEvicted" {
+				params := ev.Item.Value().([]stackitem.Item)
+				pub, err := params[0].TryBytes()
+				require.NoError(t, err)
+				actual = append(actual, pub)
+			}
+		}
+
+		require.Equal(t, len(evicted), len(actual))
+		for i := range evicted {
+			require.Contains(t, actual, evicted[i].pub)
+		}
+	}
+
+	nodes := []testNodeInfo{newStorageNode(t, cNm), newStorageNode(t, cNm)}
+	for i := range nodes {
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[i].raw)
+	}
+
+	newEpoch(t, 1)
+	newEpoch(t, 2)
+	checkSnapshotAt(t, 0, cNm, nodes)
+
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[1].raw)
+	newEpoch(t, 3, nodes[0])
+	checkSnapshotAt(t, 0, cNm, nodes[1:])
+	checkNetmapCandidates(t, cNm, 1)
+
+	t.Run("maintenance nodes are not evicted", func(t *testing.T) {
+		maintenance := newStorageNode(t, cNm)
+		maintenance.state = netmap.NodeStateMaintenance
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", maintenance.raw)
+		cNm.Invoke(t, stackitem.Null{}, "updateStateIR", int64(netmap.NodeStateMaintenance), maintenance.pub)
+
+		newEpoch(t, 6, nodes[1])
+		checkSnapshotAt(t, 0, cNm, []testNodeInfo{maintenance})
+	})
+	t.Run("eviction is disabled", func(t *testing.T) {
+		cNm.Invoke(t, stackitem.Null{}, "setConfig", []byte("id"), netmap.MaxNodeTTLKey, int64(0))
+		cNm.Invoke(t, stackitem.Null{}, "addPeerIR", nodes[0].raw)
+
+		newEpoch(t, 100)
+		checkNetmapCandidates(t, cNm, 2)
+	})
+}
+
+func TestEvictCandidatesAfterUpdate(t *testing.T) {
+	cNm := newNetmapInvoker(t, netmap.MaxNodeTTLKey, int64(2))
+
+	node := newStorageNode(t, cNm)
+	cNm.Invoke(t, stackitem.Null{}, "addPeerIR", node.raw)
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(1))
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(2))
+
+	// Update must keep the bootstrap epoch of the existing candidate.
+	updateNetmapContract(t, cNm)
+	checkNetmapCandidates(t, cNm, 1)
+
+	cNm.Invoke(t, stackitem.Null{}, "newEpoch", int64(3))
+	checkNetmapCandidates(t, cNm, 0)
+}
+
 func checkNetmapCandidates(t *testing.T, c *neotest.ContractInvoker, size int) []stackitem.Item {
 	s, err := c.TestInvoke(t, "netmapCandidates")
 	require.NoError(t, err)